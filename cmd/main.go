@@ -15,17 +15,20 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/blacktag/bugby-Go/internal/api"
 	"github.com/blacktag/bugby-Go/internal/database"
 	"github.com/blacktag/bugby-Go/internal/middleware"
+	"github.com/blacktag/bugby-Go/internal/storage"
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
 	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
@@ -47,10 +50,29 @@ func main() {
 	dbQueries := database.New(db)
 	secret := os.Getenv("SECRET")
 
+	attachmentsDir := os.Getenv("ATTACHMENTS_DIR")
+	if attachmentsDir == "" {
+		attachmentsDir = "data/attachments"
+	}
+	attachments, err := storage.NewFilesystem(attachmentsDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	cfg := api.APIConfig{
-		DB:     dbQueries,
-		SECRET: secret,
+		DB:      dbQueries,
+		SECRET:  secret,
+		SQLDB:   db,
+		Storage: attachments,
+	}
+	retentionDays := 30
+	if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+		retentionDays, err = strconv.Atoi(v)
+		if err != nil || retentionDays < 0 {
+			log.Fatal("TRASH_RETENTION_DAYS must be a non-negative number of days")
+		}
 	}
+
 	enforcer, err := SetupCasbin()
 	if err != nil {
 		log.Fatal("failed to setup casbin: %w", err)
@@ -59,17 +81,100 @@ func main() {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	slog.SetDefault(logger)
 
+	// A retention of zero keeps deleted bugs in the trash until an admin restores them.
+	if retentionDays > 0 {
+		go cfg.RunTrashPurger(context.Background(), time.Duration(retentionDays)*24*time.Hour, time.Hour)
+	}
+
 	authMiddleware := middleware.Authenticate(cfg.SECRET, cfg.DB)
 	authMiddleware2 := middleware.RevokeTokenAthenticate(cfg.DB)
+	// Bugs can be read without logging in, but confidential ones only show up for
+	// users who are allowed to see them.
+	identify := func(h http.HandlerFunc) http.Handler {
+		return middleware.Identify(cfg.SECRET, cfg.DB)(h)
+	}
 
 	mux := http.NewServeMux()
 
-	protected := authMiddleware(middleware.Authorization(enforcer)(http.HandlerFunc(cfg.DeleteBugByIDHandler)))
+	adminOnly := func(h http.HandlerFunc) http.Handler {
+		return authMiddleware(middleware.Authorization(enforcer)(h))
+	}
+
+	protected := adminOnly(cfg.DeleteBugByIDHandler)
 	mux.Handle("POST /api/bugs", authMiddleware(http.HandlerFunc(cfg.CreateBugHandler)))
+	mux.Handle("POST /api/bugs/bulk", authMiddleware(http.HandlerFunc(cfg.BulkUpdateBugsHandler)))
 	mux.Handle("DELETE /api/bugs/{bugid}", protected)
+	mux.Handle("GET /api/bugs/trash", adminOnly(cfg.GetTrashHandler))
+	mux.Handle("POST /api/bugs/{bugid}/restore", adminOnly(cfg.RestoreBugHandler))
+	mux.Handle("POST /api/bugs/{bugid}/merge", adminOnly(cfg.MergeBugHandler))
 	mux.Handle("POST /api/bugs/{bugid}", authMiddleware(http.HandlerFunc(cfg.UpdateBugHandler)))
-	mux.HandleFunc("GET /api/bugs/{bugid}", cfg.GetBugByIDHandler)
-	mux.HandleFunc("GET /api/bugs", cfg.GetBugsHandler)
+	mux.Handle("GET /api/bugs/{bugid}", identify(cfg.GetBugByIDHandler))
+	mux.Handle("POST /api/bugs/{bugid}/status", authMiddleware(http.HandlerFunc(cfg.TransitionBugStatusHandler)))
+	mux.Handle("GET /api/bugs/{bugid}/transitions", identify(cfg.GetBugTransitionsHandler))
+	mux.Handle("GET /api/bugs/{bugid}/history", identify(cfg.GetBugHistoryHandler))
+	mux.Handle("POST /api/bugs/{bugid}/attachments", authMiddleware(http.HandlerFunc(cfg.UploadAttachmentHandler)))
+	mux.Handle("GET /api/bugs/{bugid}/attachments", authMiddleware(http.HandlerFunc(cfg.GetAttachmentsHandler)))
+	mux.Handle("GET /api/bugs/{bugid}/attachments/{attachmentid}", authMiddleware(http.HandlerFunc(cfg.DownloadAttachmentHandler)))
+	mux.Handle("POST /api/bugs/{bugid}/links", authMiddleware(http.HandlerFunc(cfg.CreateBugLinkHandler)))
+	mux.Handle("GET /api/bugs/{bugid}/links", identify(cfg.GetBugLinksHandler))
+	mux.Handle("DELETE /api/bugs/{bugid}/links/{linkid}", authMiddleware(http.HandlerFunc(cfg.DeleteBugLinkHandler)))
+	mux.Handle("GET /api/bugs/{bugid}/watchers", identify(cfg.GetBugWatchersHandler))
+	mux.Handle("PUT /api/bugs/{bugid}/watchers/me", authMiddleware(http.HandlerFunc(cfg.WatchBugHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/watchers/me", authMiddleware(http.HandlerFunc(cfg.UnwatchBugHandler)))
+	mux.Handle("PUT /api/bugs/{bugid}/vote", authMiddleware(http.HandlerFunc(cfg.VoteBugHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/vote", authMiddleware(http.HandlerFunc(cfg.UnvoteBugHandler)))
+	mux.Handle("PUT /api/bugs/{bugid}/assignee", authMiddleware(http.HandlerFunc(cfg.AssignBugHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/assignee", authMiddleware(http.HandlerFunc(cfg.UnassignBugHandler)))
+	mux.Handle("PUT /api/bugs/{bugid}/milestone", authMiddleware(http.HandlerFunc(cfg.SetBugMilestoneHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/milestone", authMiddleware(http.HandlerFunc(cfg.ClearBugMilestoneHandler)))
+	mux.Handle("PUT /api/bugs/{bugid}/component", authMiddleware(http.HandlerFunc(cfg.SetBugComponentHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/component", authMiddleware(http.HandlerFunc(cfg.ClearBugComponentHandler)))
+	mux.Handle("PUT /api/bugs/{bugid}/due", authMiddleware(http.HandlerFunc(cfg.SetBugDueHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/due", authMiddleware(http.HandlerFunc(cfg.ClearBugDueHandler)))
+	mux.Handle("PUT /api/bugs/{bugid}/estimate", authMiddleware(http.HandlerFunc(cfg.SetBugEstimateHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/estimate", authMiddleware(http.HandlerFunc(cfg.ClearBugEstimateHandler)))
+	mux.Handle("PUT /api/bugs/{bugid}/confidential", authMiddleware(http.HandlerFunc(cfg.SetBugConfidentialHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/confidential", authMiddleware(http.HandlerFunc(cfg.ClearBugConfidentialHandler)))
+	mux.Handle("POST /api/bugs/{bugid}/worklogs", authMiddleware(http.HandlerFunc(cfg.CreateWorkLogHandler)))
+	mux.Handle("GET /api/bugs/{bugid}/worklogs", identify(cfg.GetWorkLogsHandler))
+	mux.Handle("PUT /api/bugs/{bugid}/worklogs/{worklogid}", authMiddleware(http.HandlerFunc(cfg.UpdateWorkLogHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/worklogs/{worklogid}", authMiddleware(http.HandlerFunc(cfg.DeleteWorkLogHandler)))
+	mux.Handle("GET /api/reports/worklogs", authMiddleware(http.HandlerFunc(cfg.GetWorkLogReportHandler)))
+	mux.Handle("GET /api/reports/reopened", authMiddleware(http.HandlerFunc(cfg.GetMostReopenedBugsHandler)))
+	mux.Handle("POST /api/bugs/{bugid}/comments", authMiddleware(http.HandlerFunc(cfg.CreateCommentHandler)))
+	mux.Handle("GET /api/bugs/{bugid}/comments", identify(cfg.GetCommentsHandler))
+	mux.Handle("PUT /api/bugs/{bugid}/comments/{commentid}", authMiddleware(http.HandlerFunc(cfg.UpdateCommentHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/comments/{commentid}", authMiddleware(http.HandlerFunc(cfg.DeleteCommentHandler)))
+	mux.Handle("PUT /api/bugs/{bugid}/labels/{label}", authMiddleware(http.HandlerFunc(cfg.AddBugLabelHandler)))
+	mux.Handle("DELETE /api/bugs/{bugid}/labels/{label}", authMiddleware(http.HandlerFunc(cfg.RemoveBugLabelHandler)))
+	mux.HandleFunc("GET /api/labels", cfg.GetLabelsHandler)
+	mux.Handle("POST /api/projects", authMiddleware(http.HandlerFunc(cfg.CreateProjectHandler)))
+	mux.HandleFunc("GET /api/projects", cfg.GetProjectsHandler)
+	mux.HandleFunc("GET /api/projects/{key}", cfg.GetProjectHandler)
+	mux.Handle("PUT /api/projects/{key}", authMiddleware(http.HandlerFunc(cfg.UpdateProjectHandler)))
+	mux.Handle("GET /api/projects/{key}/bugs", identify(cfg.GetProjectBugsHandler))
+	mux.Handle("POST /api/projects/{key}/milestones", adminOnly(cfg.CreateMilestoneHandler))
+	mux.HandleFunc("GET /api/projects/{key}/milestones", cfg.GetProjectMilestonesHandler)
+	mux.HandleFunc("GET /api/milestones/{id}", cfg.GetMilestoneHandler)
+	mux.Handle("PUT /api/milestones/{id}", adminOnly(cfg.UpdateMilestoneHandler))
+	mux.Handle("POST /api/projects/{key}/components", authMiddleware(http.HandlerFunc(cfg.CreateComponentHandler)))
+	mux.HandleFunc("GET /api/projects/{key}/components", cfg.GetProjectComponentsHandler)
+	mux.Handle("PUT /api/components/{id}", authMiddleware(http.HandlerFunc(cfg.UpdateComponentHandler)))
+	mux.Handle("POST /api/projects/{key}/fields", adminOnly(cfg.CreateCustomFieldHandler))
+	mux.HandleFunc("GET /api/projects/{key}/fields", cfg.GetProjectCustomFieldsHandler)
+	mux.Handle("DELETE /api/fields/{id}", adminOnly(cfg.DeleteCustomFieldHandler))
+	mux.Handle("POST /api/projects/{key}/templates", adminOnly(cfg.CreateBugTemplateHandler))
+	mux.HandleFunc("GET /api/projects/{key}/templates", cfg.GetProjectBugTemplatesHandler)
+	mux.Handle("PUT /api/templates/{id}", adminOnly(cfg.UpdateBugTemplateHandler))
+	mux.Handle("DELETE /api/templates/{id}", adminOnly(cfg.DeleteBugTemplateHandler))
+	mux.HandleFunc("GET /api/sla", cfg.GetSlaPoliciesHandler)
+	mux.Handle("PUT /api/sla/{priority}", adminOnly(cfg.SetSlaPolicyHandler))
+	mux.Handle("DELETE /api/sla/{priority}", adminOnly(cfg.DeleteSlaPolicyHandler))
+	mux.Handle("POST /api/projects/{key}/bugs", authMiddleware(http.HandlerFunc(cfg.CreateProjectBugHandler)))
+	mux.Handle("POST /api/labels", adminOnly(cfg.CreateLabelHandler))
+	mux.Handle("PUT /api/labels/{labelid}", adminOnly(cfg.UpdateLabelHandler))
+	mux.Handle("DELETE /api/labels/{labelid}", adminOnly(cfg.DeleteLabelHandler))
+	mux.Handle("GET /api/bugs", identify(cfg.GetBugsHandler))
 	mux.HandleFunc("POST /api/users", cfg.CreateUserHandler)
 	mux.HandleFunc("POST /api/login", cfg.LoginUserHandler)
 	mux.HandleFunc("POST /api/refresh", cfg.RefreshTokenHandler)
@@ -77,6 +182,10 @@ func main() {
 	mux.Handle("PUT /api/users", authMiddleware(http.HandlerFunc(cfg.UpdateCredentialsHandler)))
 	mux.HandleFunc("/swagger/", httpswagger.WrapHandler)
 	mux.HandleFunc("GET /api/users", cfg.GetUsersHandler)
+	mux.Handle("PUT /api/users/{id}/role", adminOnly(cfg.SetUserRoleHandler))
+	mux.Handle("GET /api/users/me/assigned", authMiddleware(http.HandlerFunc(cfg.GetMyAssignedBugsHandler)))
+	mux.Handle("GET /api/users/me/watching", authMiddleware(http.HandlerFunc(cfg.GetMyWatchedBugsHandler)))
+	mux.Handle("GET /api/users/me/mentions", authMiddleware(http.HandlerFunc(cfg.GetMyMentionsHandler)))
 
 	mux.HandleFunc("GET /test", func(w http.ResponseWriter, r *http.Request) {
 		slog.Info("TEST LOG MESSAGE", "key", "value")
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type AssignBugRequest struct {
+	AssigneeID uuid.UUID `json:"assignee_id" example:"9b733930-ef6f-4b01-add2-f410962ec695"`
+}
+
+// canManageBug reports whether the user may change who works on a bug or where it
+// stands in the workflow: its author, its current assignee and admins.
+func canManageBug(bug database.Bug, userID uuid.UUID, role string) bool {
+	if role == "admin" || bug.PostedBy == userID {
+		return true
+	}
+	return bug.AssigneeID.Valid && bug.AssigneeID.UUID == userID
+}
+
+// @Summary Assign a bug
+// @Description The author, the current assignee or an admin can assign a bug to a user
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param request body AssignBugRequest true "user to assign"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/assignee [put]
+// @Security BearerAuth
+func (cfg *APIConfig) AssignBugHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "AssignBugHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	var req AssignBugRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("given request body in wrong format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.AssigneeID == uuid.Nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "assignee_id field required")
+		return
+	}
+	if _, err := cfg.DB.GetUserByID(r.Context(), req.AssigneeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "assignee does not exist")
+			return
+		}
+		logger.Error("cannot fetch assignee", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch assignee")
+		return
+	}
+	cfg.setBugAssignee(w, r, logger, uuid.NullUUID{UUID: req.AssigneeID, Valid: true})
+}
+
+// @Summary Unassign a bug
+// @Description The author, the current assignee or an admin can remove the assignee of a bug
+// @Tags bugs
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/assignee [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) UnassignBugHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UnassignBugHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	cfg.setBugAssignee(w, r, logger, uuid.NullUUID{})
+}
+
+func (cfg *APIConfig) setBugAssignee(w http.ResponseWriter, r *http.Request, logger *slog.Logger, assignee uuid.NullUUID) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		logger.Error("user id not given or invalid")
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	logger = logger.With("userID", userID)
+
+	bugID, err := uuid.Parse(r.PathValue("bugid"))
+	if err != nil {
+		logger.Error("given id format is wrong", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return
+	}
+	logger = logger.With("bugID", bugID)
+
+	bug, err := cfg.getBugByRef(r.Context(), bugID.String())
+	if err != nil {
+		logger.Error("bug not found in database", "error", err)
+		utils.RespondWithError(w, http.StatusNotFound, "no bug found with the id")
+		return
+	}
+	if !canManageBug(bug, userID, role) {
+		logger.Error("user cannot change assignee of this bug")
+		utils.RespondWithError(w, http.StatusForbidden, "only author, assignee or admin can change the assignee")
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change assignee")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	err = qtx.SetBugAssignee(r.Context(), database.SetBugAssigneeParams{
+		ID:         bugID,
+		AssigneeID: assignee,
+	})
+	if err != nil {
+		logger.Error("updating assignee failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change assignee")
+		return
+	}
+	err = recordBugChanges(r.Context(), qtx, bugID, userID, []bugChange{
+		{"assignee_id", uuidValue(bug.AssigneeID), uuidValue(assignee)},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change assignee")
+		return
+	}
+	if assignee.Valid {
+		err = qtx.AddBugWatcher(r.Context(), database.AddBugWatcherParams{BugID: bugID, UserID: assignee.UUID})
+		if err != nil {
+			logger.Error("adding assignee as watcher failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot change assignee")
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change assignee")
+		return
+	}
+	updatedBug, err := cfg.DB.GetBugsByID(r.Context(), bugID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch updated bug")
+		return
+	}
+	logger.Info("assignee changed", "assignee", assignee)
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponse(updatedBug))
+}
+
+// @Summary List bugs assigned to me
+// @Description Returns the queue of bugs assigned to the logged in user, newest first
+// @Tags users
+// @Produce json
+// @Success 200 {array} BugResponse
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /users/me/assigned [get]
+// @Security BearerAuth
+func (cfg *APIConfig) GetMyAssignedBugsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	bugs, err := cfg.DB.GetBugsByAssignee(r.Context(), userID)
+	if err != nil {
+		slog.Error("fetching assigned bugs failed", "userID", userID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch assigned bugs")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponses(bugs))
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignBugHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	authorID := uuid.New()
+	assigneeID := uuid.New()
+	bugID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetUserByID :one`)).WithArgs(assigneeID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "email", "hashed_password", "role"}).
+			AddRow(assigneeID, time.Now(), time.Now(), "dev@example.com", "hash", "user"))
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", authorID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE bugs SET assignee_id = $2, updated_at = NOW() WHERE id = $1`)).
+		WithArgs(bugID, assigneeID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, authorID, "assignee_id", nil, assigneeID.String()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddBugWatcher :exec`)).
+		WithArgs(bugID, assigneeID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", authorID, time.Now(), time.Now(), "open", "major", "P2", assigneeID, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/assignee", cfg.AssignBugHandler)
+	body := bytes.NewBufferString(`{"assignee_id":"` + assigneeID.String() + `"}`)
+	req := httptest.NewRequest("PUT", "/api/bugs/"+bugID.String()+"/assignee", body)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", authorID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.NotNil(t, response.AssigneeID) {
+		assert.Equal(t, assigneeID, *response.AssigneeID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnassignBugHandlerForbidsOtherUsers(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", uuid.New(), testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/assignee", cfg.UnassignBugHandler)
+	req := httptest.NewRequest("DELETE", "/api/bugs/"+bugID.String()+"/assignee", nil)
+	ctx := context.WithValue(req.Context(), "userID", uuid.New())
+	ctx = context.WithValue(ctx, "role", "user")
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,336 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/storage"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+const maxAttachmentSize = 10 << 20
+
+// allowedAttachmentTypes lists the content types accepted for attachments. The type is
+// sniffed from the uploaded bytes, the one claimed by the client is ignored.
+var allowedAttachmentTypes = map[string]bool{
+	"image/png":          true,
+	"image/jpeg":         true,
+	"image/gif":          true,
+	"image/webp":         true,
+	"application/pdf":    true,
+	"text/plain":         true,
+	"application/zip":    true,
+	"application/x-gzip": true,
+}
+
+type AttachmentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	BugID       uuid.UUID `json:"bug_id"`
+	Filename    string    `json:"filename" example:"screenshot.png"`
+	ContentType string    `json:"content_type" example:"image/png"`
+	Size        int64     `json:"size" example:"48213"`
+	SHA256      string    `json:"sha256"`
+	UploadedBy  uuid.UUID `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func toAttachmentResponse(attachment database.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          attachment.ID,
+		BugID:       attachment.BugID,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.SizeBytes,
+		SHA256:      attachment.Sha256,
+		UploadedBy:  attachment.UploadedBy,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+// attachmentFilename keeps only the base name of an uploaded file, since browsers may send
+// a full client side path.
+func attachmentFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
+
+// @Summary Attach a file to a bug
+// @Description Logged in users can attach screenshots, logs and similar files up to 10 MiB.
+// @Description Uploading the same content to the same bug again returns the existing attachment.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param file formData file true "File to attach"
+// @Success 200 {object} AttachmentResponse "Already attached"
+// @Success 201 {object} AttachmentResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 413 {object} utils.ErrorResponse "Request Entity Too Large"
+// @Failure 415 {object} utils.ErrorResponse "Unsupported Media Type"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/attachments [post]
+// @Security BearerAuth
+func (cfg *APIConfig) UploadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UploadAttachmentHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+
+	// Leave some room for the multipart framing around the file itself.
+	r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentSize+(1<<20))
+	reader, err := r.MultipartReader()
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "request must be multipart/form-data")
+		return
+	}
+	var filename string
+	var data []byte
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			respondWithUploadError(w, logger, err)
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+		filename = attachmentFilename(part.FileName())
+		data, err = io.ReadAll(io.LimitReader(part, maxAttachmentSize+1))
+		part.Close()
+		if err != nil {
+			respondWithUploadError(w, logger, err)
+			return
+		}
+		break
+	}
+	if data == nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "file field required")
+		return
+	}
+	if len(data) > maxAttachmentSize {
+		utils.RespondWithError(w, http.StatusRequestEntityTooLarge, "attachments are limited to 10 MiB")
+		return
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !allowedAttachmentTypes[contentType] {
+		utils.RespondWithError(w, http.StatusUnsupportedMediaType, "files of type "+contentType+" cannot be attached")
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	logger = logger.With("bugID", bug.ID, "sha256", hash)
+
+	existing, err := cfg.DB.GetAttachmentByHash(r.Context(), database.GetAttachmentByHashParams{
+		BugID:  bug.ID,
+		Sha256: hash,
+	})
+	if err == nil {
+		utils.RespondWithJSON(w, http.StatusOK, toAttachmentResponse(existing))
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		logger.Error("looking up attachment failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot attach file")
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot attach file")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	// PurgeTrash takes the same lock before removing a file, so it cannot delete the
+	// file between it being stored here and the row that references it committing.
+	if err := qtx.LockAttachmentHash(r.Context(), hash); err != nil {
+		logger.Error("locking attachment hash failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot attach file")
+		return
+	}
+	// Contents are stored by hash, so the same file attached to many bugs is kept once.
+	if err := cfg.Storage.Put(r.Context(), hash, bytes.NewReader(data)); err != nil {
+		logger.Error("storing attachment failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot attach file")
+		return
+	}
+	attachment, err := qtx.CreateAttachment(r.Context(), database.CreateAttachmentParams{
+		BugID:       bug.ID,
+		UploadedBy:  userID,
+		Filename:    filename,
+		ContentType: contentType,
+		SizeBytes:   int64(len(data)),
+		Sha256:      hash,
+	})
+	if err == nil {
+		err = tx.Commit()
+	}
+	if isUniqueViolation(err) {
+		// the failed insert aborted the transaction, so look the winner up outside it
+		tx.Rollback()
+		existing, err = cfg.DB.GetAttachmentByHash(r.Context(), database.GetAttachmentByHashParams{
+			BugID:  bug.ID,
+			Sha256: hash,
+		})
+		if err == nil {
+			utils.RespondWithJSON(w, http.StatusOK, toAttachmentResponse(existing))
+			return
+		}
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot attach file")
+		return
+	}
+	logger.Info("attachment stored", "attachmentID", attachment.ID, "size", attachment.SizeBytes)
+	utils.RespondWithJSON(w, http.StatusCreated, toAttachmentResponse(attachment))
+}
+
+func respondWithUploadError(w http.ResponseWriter, logger *slog.Logger, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		utils.RespondWithError(w, http.StatusRequestEntityTooLarge, "attachments are limited to 10 MiB")
+		return
+	}
+	logger.Error("reading upload failed", "error", err)
+	utils.RespondWithError(w, http.StatusBadRequest, "cannot read uploaded file")
+}
+
+// @Summary List attachments of a bug
+// @Tags attachments
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {array} AttachmentResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/attachments [get]
+// @Security BearerAuth
+func (cfg *APIConfig) GetAttachmentsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "GetAttachmentsHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	attachments, err := cfg.DB.GetAttachmentsByBug(r.Context(), bug.ID)
+	if err != nil {
+		logger.Error("fetching attachments failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch attachments")
+		return
+	}
+	res := make([]AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		res = append(res, toAttachmentResponse(attachment))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Download an attachment
+// @Description Streams the attached file; it is always sent as a download, never rendered inline
+// @Tags attachments
+// @Produce octet-stream
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param attachmentid path string true "Attachment ID" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Success 200 {file} file
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/attachments/{attachmentid} [get]
+// @Security BearerAuth
+func (cfg *APIConfig) DownloadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "DownloadAttachmentHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	attachmentID, err := uuid.Parse(r.PathValue("attachmentid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format attachment Id")
+		return
+	}
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	attachment, err := cfg.DB.GetAttachmentByID(r.Context(), attachmentID)
+	if err != nil || attachment.BugID != bug.ID {
+		utils.RespondWithError(w, http.StatusNotFound, "no attachment found with the id on this bug")
+		return
+	}
+	file, err := cfg.Storage.Open(r.Context(), attachment.Sha256)
+	if errors.Is(err, storage.ErrNotFound) {
+		logger.Error("attachment contents missing from storage", "attachmentID", attachment.ID)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot read attachment")
+		return
+	}
+	if err != nil {
+		logger.Error("opening attachment failed", "attachmentID", attachment.ID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot read attachment")
+		return
+	}
+	defer file.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.SizeBytes, 10))
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, file); err != nil {
+		logger.Error("streaming attachment failed", "attachmentID", attachment.ID, "error", err)
+	}
+}
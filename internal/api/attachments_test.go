@@ -0,0 +1,153 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blacktag/bugby-Go/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var attachmentColumns = []string{"id", "bug_id", "uploaded_by", "filename", "content_type", "size_bytes", "sha256", "created_at"}
+
+var lockAttachmentHashQuery = regexp.QuoteMeta(`-- name: LockAttachmentHash :exec`)
+
+func multipartFile(t *testing.T, filename string, contents []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("cannot create form file: %v", err)
+	}
+	part.Write(contents)
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+func TestUploadAttachmentHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+	store, err := storage.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create storage: %v", err)
+	}
+	cfg.Storage = store
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	contents := []byte("panic: runtime error: index out of range\n")
+	sum := sha256.Sum256(contents)
+	hash := hex.EncodeToString(sum[:])
+
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "crash", "see log", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetAttachmentByHash :one`)).WithArgs(bugID, hash).
+		WillReturnRows(sqlmock.NewRows(attachmentColumns))
+	mock.ExpectBegin()
+	mock.ExpectExec(lockAttachmentHashQuery).WithArgs(hash).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateAttachment :one`)).
+		WithArgs(bugID, userID, "crash.log", "text/plain", int64(len(contents)), hash).
+		WillReturnRows(sqlmock.NewRows(attachmentColumns).
+			AddRow(uuid.New(), bugID, userID, "crash.log", "text/plain", len(contents), hash, time.Now()))
+	mock.ExpectCommit()
+
+	body, contentType := multipartFile(t, `C:\Users\dev\crash.log`, contents)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/attachments", cfg.UploadAttachmentHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response AttachmentResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, hash, response.SHA256)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	stored, err := store.Open(context.Background(), hash)
+	if assert.NoError(t, err) {
+		stored.Close()
+	}
+}
+
+func TestUploadAttachmentHandlerRejectsUnknownType(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "crash", "see log", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	body, contentType := multipartFile(t, "page.html", []byte("<html><script>alert(1)</script></html>"))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/attachments", cfg.UploadAttachmentHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDownloadAttachmentHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+	store, err := storage.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create storage: %v", err)
+	}
+	cfg.Storage = store
+
+	contents := "GET /users 504\n"
+	hash := "0f1e2d3c4b5a"
+	if err := store.Put(context.Background(), hash, strings.NewReader(contents)); err != nil {
+		t.Fatalf("cannot store contents: %v", err)
+	}
+	bugID := uuid.New()
+	attachmentID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "timeout", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetAttachmentByID :one`)).WithArgs(attachmentID).
+		WillReturnRows(sqlmock.NewRows(attachmentColumns).
+			AddRow(attachmentID, bugID, uuid.New(), "access \"prod\".log", "text/plain", len(contents), hash, time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/attachments/{attachmentid}", cfg.DownloadAttachmentHandler)
+	req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String()+"/attachments/"+attachmentID.String(), nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	assert.Equal(t, contents, w.Body.String())
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="access \"prod\".log"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
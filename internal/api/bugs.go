@@ -1,13 +1,22 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/markdown"
 	"github.com/blacktag/bugby-Go/internal/utils"
 
 	// "github.com/casbin/casbin/v2/log"
@@ -15,27 +24,260 @@ import (
 )
 
 type CreateBugResponse struct {
-	ID          uuid.UUID `json:"bug_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	PostedBy    uuid.UUID `json:"posted_by"`
-	CreatedBy   time.Time `json:"created_at"`
-	Updated_at  time.Time `json:"updated_at"`
+	ID           uuid.UUID  `json:"bug_id"`
+	Key          string     `json:"key"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	PostedBy     uuid.UUID  `json:"posted_by"`
+	CreatedBy    time.Time  `json:"created_at"`
+	Updated_at   time.Time  `json:"updated_at"`
+	Status       string     `json:"status"`
+	Severity     string     `json:"severity"`
+	Priority     string     `json:"priority"`
+	ProjectID    uuid.UUID  `json:"project_id"`
+	AssigneeID   *uuid.UUID `json:"assignee_id"`
+	ComponentID  *uuid.UUID `json:"component_id"`
+	DueAt        *time.Time `json:"due_at"`
+	Confidential bool       `json:"confidential"`
+	Votes        int32      `json:"votes"`
+}
+
+// BugResponse describes a bug. DescriptionHTML, the description rendered from
+// Markdown and sanitized, and the SLA fields are only filled in when a single bug is
+// fetched; SlaState is ok, at_risk or breached while an unresolved bug has a deadline.
+// ID, Title, Description, PostedBy, CreatedAt and UpdatedAt keep the keys clients
+// already read; every other field uses snake_case.
+type BugResponse struct {
+	ID              uuid.UUID      `json:"ID"`
+	Key             string         `json:"key"`
+	Title           string         `json:"Title"`
+	Description     string         `json:"Description"`
+	DescriptionHTML string         `json:"description_html,omitempty"`
+	Status          string         `json:"status"`
+	Severity        string         `json:"severity"`
+	Priority        string         `json:"priority"`
+	PostedBy        uuid.UUID      `json:"PostedBy"`
+	AssigneeID      *uuid.UUID     `json:"assignee_id"`
+	ProjectID       uuid.UUID      `json:"project_id"`
+	MilestoneID     *uuid.UUID     `json:"milestone_id"`
+	ComponentID     *uuid.UUID     `json:"component_id"`
+	Labels          []string       `json:"labels,omitempty"`
+	Fields          map[string]any `json:"fields,omitempty"`
+	DuplicateOf     *LinkedBug     `json:"duplicate_of,omitempty"`
+	MergedIntoID    *uuid.UUID     `json:"merged_into_id,omitempty"`
+	Confidential    bool           `json:"confidential"`
+	Resolution      *string        `json:"resolution" example:"fixed"`
+	ReopenCount     int32          `json:"reopen_count"`
+	LastReopenedAt  *time.Time     `json:"last_reopened_at"`
+	DueAt           *time.Time     `json:"due_at"`
+	EstimateMinutes *int32         `json:"estimate_minutes" example:"480"`
+	SlaState        string         `json:"sla_state,omitempty" example:"at_risk"`
+	SlaDeadline     *time.Time     `json:"sla_deadline,omitempty"`
+	Votes           int32          `json:"votes"`
+	CreatedAt       time.Time      `json:"CreatedAt"`
+	UpdatedAt       time.Time      `json:"UpdatedAt"`
+}
+
+func toBugResponse(bug database.Bug) BugResponse {
+	res := BugResponse{
+		ID:           bug.ID,
+		Key:          bug.Key,
+		Title:        bug.Title,
+		Description:  bug.Description,
+		Status:       bug.Status,
+		Severity:     bug.Severity,
+		Priority:     bug.Priority,
+		PostedBy:     bug.PostedBy,
+		ProjectID:    bug.ProjectID,
+		ReopenCount:  bug.ReopenCount,
+		Confidential: bug.Confidential,
+		Votes:        bug.VoteCount,
+		CreatedAt:    bug.CreatedAt,
+		UpdatedAt:    bug.UpdatedAt,
+	}
+	if bug.AssigneeID.Valid {
+		res.AssigneeID = &bug.AssigneeID.UUID
+	}
+	if bug.MilestoneID.Valid {
+		res.MilestoneID = &bug.MilestoneID.UUID
+	}
+	if bug.ComponentID.Valid {
+		res.ComponentID = &bug.ComponentID.UUID
+	}
+	if bug.Resolution.Valid {
+		res.Resolution = &bug.Resolution.String
+	}
+	if bug.MergedIntoID.Valid {
+		res.MergedIntoID = &bug.MergedIntoID.UUID
+	}
+	if bug.LastReopenedAt.Valid {
+		res.LastReopenedAt = &bug.LastReopenedAt.Time
+	}
+	if bug.DueAt.Valid {
+		res.DueAt = &bug.DueAt.Time
+	}
+	if bug.EstimateMinutes.Valid {
+		res.EstimateMinutes = &bug.EstimateMinutes.Int32
+	}
+	return res
+}
+
+func toBugResponses(bugs []database.Bug) []BugResponse {
+	res := make([]BugResponse, 0, len(bugs))
+	for _, bug := range bugs {
+		res = append(res, toBugResponse(bug))
+	}
+	return res
 }
 
 type CreateBugRequest struct {
-	Title       string    `json:"title" example:"This is the bug needed"`
-	Description string    `json:"description" example:"this is descrption"`
-	PostedBy    uuid.UUID `json:"posted_by" example:"9b733930-ef6f-4b01-add2-f410962ec695"`
+	Title       string     `json:"title" example:"This is the bug needed"`
+	Description string     `json:"description" example:"this is descrption"`
+	PostedBy    uuid.UUID  `json:"posted_by" example:"9b733930-ef6f-4b01-add2-f410962ec695"`
+	Severity    string     `json:"severity" example:"major"`
+	Priority    string     `json:"priority" example:"P2"`
+	ProjectKey  string     `json:"project_key" example:"API"`
+	Component   string     `json:"component" example:"auth"`
+	AssigneeID  *uuid.UUID `json:"assignee_id" example:"9b733930-ef6f-4b01-add2-f410962ec695"`
+	TemplateID  *uuid.UUID `json:"template_id" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"`
+	// DueAt overrides the SLA of the bug's priority with a deadline of its own.
+	DueAt *time.Time `json:"due_at" example:"2025-07-01T17:00:00Z"`
+	// Fields holds values for the custom fields of the project, by field name.
+	Fields map[string]any `json:"fields"`
+	// Confidential files a security vulnerability: only the reporter, the assignee, the
+	// security team and admins can see the bug.
+	Confidential bool `json:"confidential" example:"false"`
 }
 
 type UpdateBugRequest struct {
 	Title       *string `json:"title" example:"This is the bug needed"`
 	Description *string `json:"description" example:"this is descrption"`
+	Severity    *string `json:"severity" example:"critical"`
+	Priority    *string `json:"priority" example:"P1"`
+	// Fields sets custom fields by name; null clears a field.
+	Fields map[string]any `json:"fields"`
+}
+
+const (
+	defaultSeverity   = "major"
+	defaultPriority   = "P2"
+	defaultProjectKey = "BUG"
+)
+
+var validSeverities = map[string]bool{
+	"blocker":  true,
+	"critical": true,
+	"major":    true,
+	"minor":    true,
+	"trivial":  true,
+}
+
+var validPriorities = map[string]bool{
+	"P0": true,
+	"P1": true,
+	"P2": true,
+	"P3": true,
+	"P4": true,
+}
+
+var validBugSorts = map[string]bool{
+	"created":  true,
+	"priority": true,
+	"severity": true,
+	"votes":    true,
+}
+
+type TransitionBugRequest struct {
+	Status string `json:"status" example:"closed"`
+	// Resolution says why a bug is resolved or closed. It is required when the bug has
+	// none yet and is rejected for any other status.
+	Resolution string `json:"resolution" example:"fixed"`
+}
+
+// bugStatusTransitions lists, for every status, the statuses a bug may move to next.
+// A closed bug has to be reopened before any work can start on it again.
+var bugStatusTransitions = map[string][]string{
+	"open":        {"triaged", "in_progress", "resolved", "closed"},
+	"triaged":     {"in_progress", "resolved", "closed"},
+	"in_progress": {"triaged", "resolved", "closed"},
+	"resolved":    {"closed", "reopened"},
+	"closed":      {"reopened"},
+	"reopened":    {"triaged", "in_progress", "resolved", "closed"},
+}
+
+// bugKeyPattern matches the human readable form of a bug reference, e.g. API-123.
+var bugKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]{1,9}-[1-9][0-9]*$`)
+
+var errInvalidBugRef = errors.New("bug reference must be a UUID or a key like API-123")
+
+// getBugByRef loads a bug by its UUID or by its per-project key. A confidential bug the
+// viewer in ctx may not see is reported as missing, so its existence does not leak.
+func (cfg *APIConfig) getBugByRef(ctx context.Context, ref string) (database.Bug, error) {
+	var bug database.Bug
+	var err error
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		bug, err = cfg.DB.GetBugsByID(ctx, id)
+	} else if bugKeyPattern.MatchString(ref) {
+		bug, err = cfg.DB.GetBugByKey(ctx, strings.ToUpper(ref))
+	} else {
+		return database.Bug{}, errInvalidBugRef
+	}
+	if err != nil {
+		return database.Bug{}, err
+	}
+	if viewer, role := viewerFromContext(ctx); !canViewBug(bug, viewer, role) {
+		return database.Bug{}, sql.ErrNoRows
+	}
+	return bug, nil
+}
+
+// mergeTarget returns the bug that bug was merged into, if there is one and the viewer
+// may see it.
+func (cfg *APIConfig) mergeTarget(ctx context.Context, bug database.Bug) (uuid.NullUUID, error) {
+	if !bug.MergedIntoID.Valid {
+		return uuid.NullUUID{}, nil
+	}
+	_, err := cfg.getBugByRef(ctx, bug.MergedIntoID.UUID.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.NullUUID{}, nil
+	}
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return bug.MergedIntoID, nil
+}
+
+// bugFromRef is getBugByRef for handlers: it writes the error response itself when it
+// returns false.
+func (cfg *APIConfig) bugFromRef(w http.ResponseWriter, r *http.Request, logger *slog.Logger, ref string) (database.Bug, bool) {
+	bug, err := cfg.getBugByRef(r.Context(), ref)
+	if errors.Is(err, errInvalidBugRef) {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return database.Bug{}, false
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "no bug found with the id "+ref)
+		return database.Bug{}, false
+	}
+	if err != nil {
+		logger.Error("fetching bug failed", "ref", ref, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch bug")
+		return database.Bug{}, false
+	}
+	return bug, true
+}
+
+func isValidTransition(from, to string) bool {
+	for _, next := range bugStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
 }
 
 // @Summary Create bugs
-// @Description Existing users can create bugs
+// @Description Existing users can create bugs, filed under the default project unless project_key is given. Users mentioned in the description (@handle or @email) start watching the bug. With template_id the description has to fill in every section of the template and set its required fields, and the bug gets the template's labels
 // @Tags users
 // @Accept json
 // @Produce json
@@ -70,58 +312,325 @@ func (cfg *APIConfig) CreateBugHandler(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWithError(w, http.StatusInternalServerError, "error decoding json")
 		return
 	}
-	logger = logger.With("bug_title", req.Title)
-	bug, err := cfg.DB.CreateBug(r.Context(), database.CreateBugParams{
-		Title:       req.Title,
-		Description: req.Description,
-		PostedBy:    userID,
+	projectKey := req.ProjectKey
+	if projectKey == "" {
+		projectKey = defaultProjectKey
+	}
+	project, err := cfg.DB.GetProjectByKey(r.Context(), projectKey)
+	if err != nil {
+		logger.Error("project not found", "project_key", projectKey, "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "unknown project, set project_key")
+		return
+	}
+	cfg.createBug(w, r, logger, userID, project, req)
+	slog.Info("about to respond")
+
+}
+
+// createBug validates the request and files the bug under the given project.
+func (cfg *APIConfig) createBug(w http.ResponseWriter, r *http.Request, logger *slog.Logger, userID uuid.UUID, project database.Project, req CreateBugRequest) {
+	logger = logger.With("bug_title", req.Title, "project", project.Key)
+	if req.Severity == "" {
+		req.Severity = defaultSeverity
+	}
+	if req.Priority == "" {
+		req.Priority = defaultPriority
+	}
+	if !validSeverities[req.Severity] {
+		logger.Error("invalid severity", "severity", req.Severity)
+		utils.RespondWithError(w, http.StatusBadRequest, "severity must be one of blocker, critical, major, minor, trivial")
+		return
+	}
+	if !validPriorities[req.Priority] {
+		logger.Error("invalid priority", "priority", req.Priority)
+		utils.RespondWithError(w, http.StatusBadRequest, "priority must be one of P0, P1, P2, P3, P4")
+		return
+	}
+	assignee := uuid.NullUUID{}
+	if req.AssigneeID != nil {
+		if _, err := cfg.DB.GetUserByID(r.Context(), *req.AssigneeID); err != nil {
+			logger.Error("assignee not found", "assignee", *req.AssigneeID, "error", err)
+			utils.RespondWithError(w, http.StatusBadRequest, "assignee does not exist")
+			return
+		}
+		assignee = uuid.NullUUID{UUID: *req.AssigneeID, Valid: true}
+	}
+	component := uuid.NullUUID{}
+	if req.Component != "" {
+		c, err := cfg.DB.GetComponentByName(r.Context(), database.GetComponentByNameParams{
+			ProjectID: project.ID,
+			Name:      req.Component,
+		})
+		if err != nil {
+			logger.Error("component not found", "component", req.Component, "error", err)
+			utils.RespondWithError(w, http.StatusBadRequest, "unknown component "+req.Component+" in project "+project.Key)
+			return
+		}
+		component = uuid.NullUUID{UUID: c.ID, Valid: true}
+		// Bugs filed against a component go to its owner unless the reporter picked someone.
+		if !assignee.Valid {
+			assignee = c.OwnerID
+		}
+	}
+	dueAt := sql.NullTime{}
+	if req.DueAt != nil {
+		dueAt = sql.NullTime{Time: req.DueAt.UTC(), Valid: true}
+	}
+	fields, err := cfg.resolveFieldValues(r.Context(), project.ID, req.Fields)
+	if errors.Is(err, errInvalidFieldValue) {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		logger.Error("checking custom fields failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+		return
+	}
+	var templateLabels []string
+	if req.TemplateID != nil {
+		template, err := cfg.DB.GetBugTemplateByID(r.Context(), *req.TemplateID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && template.ProjectID != project.ID) {
+			utils.RespondWithError(w, http.StatusBadRequest, "unknown template for project "+project.Key)
+			return
+		}
+		if err != nil {
+			logger.Error("fetching template failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+			return
+		}
+		if err := checkTemplateFilled(template, req.Description, fields); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		templateLabels = template.Labels
+	}
+	mentioned, err := cfg.resolveMentions(r.Context(), req.Description)
+	if err != nil {
+		logger.Error("resolving mentions failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	// Allocating the number locks the project row until commit, so concurrent bugs get
+	// consecutive numbers and a failed insert hands its number back.
+	number, err := qtx.AllocateBugNumber(r.Context(), project.ID)
+	if err != nil {
+		logger.Error("allocating bug number failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+		return
+	}
+	bug, err := qtx.CreateBug(r.Context(), database.CreateBugParams{
+		Title:        req.Title,
+		Description:  req.Description,
+		PostedBy:     userID,
+		Severity:     req.Severity,
+		Priority:     req.Priority,
+		ProjectID:    project.ID,
+		Number:       number,
+		Key:          fmt.Sprintf("%s-%d", project.Key, number),
+		AssigneeID:   assignee,
+		ComponentID:  component,
+		DueAt:        dueAt,
+		Confidential: req.Confidential,
 	})
 	if err != nil {
 		logger.Error("database operation failed", "error", err)
 		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
 		return
 	}
+	watchers := []uuid.UUID{userID}
+	if assignee.Valid && assignee.UUID != userID {
+		watchers = append(watchers, assignee.UUID)
+	}
+	for _, watcher := range watchers {
+		err = qtx.AddBugWatcher(r.Context(), database.AddBugWatcherParams{BugID: bug.ID, UserID: watcher})
+		if err != nil {
+			logger.Error("adding watcher failed", "watcher", watcher, "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+			return
+		}
+	}
+	if err := writeFieldValues(r.Context(), qtx, bug.ID, fields); err != nil {
+		logger.Error("storing custom fields failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+		return
+	}
+	if err := recordMentions(r.Context(), qtx, bug.ID, uuid.NullUUID{}, userID, mentioned); err != nil {
+		logger.Error("recording mentions failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+		return
+	}
+	if len(templateLabels) > 0 {
+		err := qtx.AddLabelsToBugByName(r.Context(), database.AddLabelsToBugByNameParams{BugID: bug.ID, Names: templateLabels})
+		if err != nil {
+			logger.Error("adding template labels failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create bug")
+		return
+	}
 
 	logger.Info("bug created successfully", "bug_id", bug.ID)
-	utils.RespondWithJSON(w, http.StatusCreated, CreateBugResponse{
-		ID:          bug.ID,
-		Title:       bug.Title,
-		Description: bug.Description,
-		PostedBy:    bug.PostedBy,
-		CreatedBy:   bug.CreatedAt,
-		Updated_at:  bug.UpdatedAt,
-	})
-	slog.Info("about to respond")
-
+	res := CreateBugResponse{
+		ID:           bug.ID,
+		Key:          bug.Key,
+		Title:        bug.Title,
+		Description:  bug.Description,
+		PostedBy:     bug.PostedBy,
+		CreatedBy:    bug.CreatedAt,
+		Updated_at:   bug.UpdatedAt,
+		Status:       bug.Status,
+		Severity:     bug.Severity,
+		Priority:     bug.Priority,
+		ProjectID:    bug.ProjectID,
+		Confidential: bug.Confidential,
+		Votes:        bug.VoteCount,
+	}
+	if bug.AssigneeID.Valid {
+		res.AssigneeID = &bug.AssigneeID.UUID
+	}
+	if bug.ComponentID.Valid {
+		res.ComponentID = &bug.ComponentID.UUID
+	}
+	if bug.DueAt.Valid {
+		res.DueAt = &bug.DueAt.Time
+	}
+	utils.RespondWithJSON(w, http.StatusCreated, res)
 }
 
 // @Summary Get existing  bugs
-// @Description  users can get all existing bugs
+// @Description  users can get all existing bugs, optionally filtered by severity and priority
+// @Description Confidential bugs are only listed for their reporter, their assignee, the security team and admins.
 // @Tags users
 // @Accept json
 // @Produce json
-// @Success 200 {object} database.Bug
+// @Param severity query []string false "Only bugs with these severities" collectionFormat(multi)
+// @Param priority query []string false "Only bugs with these priorities" collectionFormat(multi)
+// @Param label query []string false "Only bugs carrying all of these labels" collectionFormat(multi)
+// @Param project query string false "Only bugs of the project with this key"
+// @Param field.name query string false "Only bugs whose custom field name has this value, e.g. field.environment=production; dates as YYYY-MM-DD, users by id"
+// @Param sla query string false "Only unresolved bugs in this SLA state: ok, at_risk or breached"
+// @Param sort query string false "Sort order: created (default), priority, severity or votes"
+// @Success 200 {array} BugResponse
 // @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
 // @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
 // @Router /bugs [get]
 // @Security BearerAuth
 func (cfg *APIConfig) GetBugsHandler(w http.ResponseWriter, r *http.Request) {
-	bugs, err := cfg.DB.GetAllBugs(r.Context())
+	projectID := uuid.NullUUID{}
+	if key := r.URL.Query().Get("project"); key != "" {
+		project, err := cfg.DB.GetProjectByKey(r.Context(), key)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "unknown project: "+key)
+			return
+		}
+		projectID = uuid.NullUUID{UUID: project.ID, Valid: true}
+	}
+	cfg.listBugs(w, r, projectID)
+}
+
+// listBugs answers a bug listing with the filters and sort order given in the query
+// string, limited to one project when projectID is set. Confidential bugs the viewer
+// may not see are left out.
+func (cfg *APIConfig) listBugs(w http.ResponseWriter, r *http.Request, projectID uuid.NullUUID) {
+	params, err := listBugsParams(r.URL.Query(), projectID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	err = cfg.normalizeFieldFilters(r.Context(), &params)
+	if errors.Is(err, errInvalidFieldValue) {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch bugs")
+		return
+	}
+	viewer, role := viewerFromContext(r.Context())
+	params.ViewerID, params.SeeConfidential = viewer, seesConfidentialBugs(role)
+	bugs, err := cfg.DB.ListBugs(r.Context(), params)
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch bugs")
 		return
 	}
-	utils.RespondWithJSON(w, http.StatusOK, bugs)
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponses(bugs))
+}
+
+// listBugsParams turns bug list filters in query string form into ListBugs parameters.
+// Every error it returns describes input the client has to correct.
+func listBugsParams(query url.Values, projectID uuid.NullUUID) (database.ListBugsParams, error) {
+	params := database.ListBugsParams{
+		Severities: query["severity"],
+		Priorities: query["priority"],
+		Labels:     query["label"],
+		ProjectID:  projectID,
+		Sort:       query.Get("sort"),
+	}
+	if sla := query.Get("sla"); sla != "" {
+		if !validSlaStates[sla] {
+			return params, errors.New("sla must be one of ok, at_risk, breached")
+		}
+		params.Sla = sql.NullString{String: sla, Valid: true}
+	}
+	// in name order, so the filters reach the database the same way every time
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		name, ok := strings.CutPrefix(key, "field.")
+		if !ok {
+			continue
+		}
+		if !customFieldNamePattern.MatchString(name) {
+			return params, errors.New("unknown field filter: " + key)
+		}
+		for _, value := range query[key] {
+			params.FieldNames = append(params.FieldNames, name)
+			params.FieldValues = append(params.FieldValues, value)
+		}
+	}
+	for _, severity := range params.Severities {
+		if !validSeverities[severity] {
+			return params, errors.New("unknown severity: " + severity)
+		}
+	}
+	for _, priority := range params.Priorities {
+		if !validPriorities[priority] {
+			return params, errors.New("unknown priority: " + priority)
+		}
+	}
+	if params.Sort == "" {
+		params.Sort = "created"
+	}
+	if !validBugSorts[params.Sort] {
+		return params, errors.New("unknown sort: " + params.Sort)
+	}
+	return params, nil
 }
 
 // @Summary GET bug by id
 // @Description Existing users can update their info using email and password
+// @Description A bug that was merged into another one redirects to it.
+// @Description A confidential bug is not found for anyone but its reporter, its assignee, the security team and admins.
 // @Tags bugs
 // @Accept json
 // @Produce json
-// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
-// @Success 200 {object} database.Bug
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {object} BugResponse
+// @Success 302 "Found - the bug was merged, Location names the bug it was merged into. Viewers who cannot see that bug get the merged bug instead"
 // @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
 // @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
 // @Router /bugs/{bugid} [get]
 // @Security BearerAuth
@@ -139,23 +648,73 @@ func (cfg *APIConfig) GetBugByIDHandler(w http.ResponseWriter, r *http.Request)
 		utils.RespondWithError(w, http.StatusBadRequest, " no id given ")
 		return
 	}
-	bugID, err := uuid.Parse(bugIDParam)
-	if err != nil {
+	logger.Info("calling database")
+	bug, err := cfg.getBugByRef(r.Context(), bugIDParam)
+	if errors.Is(err, errInvalidBugRef) {
 		logger.Error("invalid id format", "error", err)
 		utils.RespondWithError(w, http.StatusBadRequest, "wrong Id format ")
 		return
 	}
-	logger.Info("parsed uuid", "bugID", bugID)
-
-	logger.Info("calling database")
-	bug, err := cfg.DB.GetBugsByID(r.Context(), bugID)
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "no bug found with the id")
+		return
+	}
 	if err != nil {
 		logger.Error("database error", "error", err)
 		utils.RespondWithError(w, http.StatusInternalServerError, " bug not found ")
 		return
 	}
+	// A bug made confidential after the merge must not be revealed by the redirect, so
+	// viewers who cannot see it get the merged bug itself.
+	target, err := cfg.mergeTarget(r.Context(), bug)
+	if err != nil {
+		logger.Error("database error", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch merged bug")
+		return
+	}
+	if target.Valid {
+		http.Redirect(w, r, "/api/bugs/"+target.UUID.String(), http.StatusFound)
+		return
+	}
+	labels, err := cfg.DB.GetLabelsByBug(r.Context(), bug.ID)
+	if err != nil {
+		logger.Error("database error", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch labels")
+		return
+	}
+	canonical, err := cfg.DB.GetCanonicalBug(r.Context(), bug.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Error("database error", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch duplicate link")
+		return
+	}
+	fieldValues, err := cfg.DB.GetBugFieldValues(r.Context(), bug.ID)
+	if err != nil {
+		logger.Error("database error", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch custom fields")
+		return
+	}
+	policy, err := cfg.bugSlaPolicy(r, bug)
+	if err != nil {
+		logger.Error("database error", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch sla policy")
+		return
+	}
+	res := toBugResponse(bug)
+	// only reached when there is no visible merge target
+	res.MergedIntoID = nil
+	res.DescriptionHTML = markdown.Render(bug.Description)
+	res.Labels = labelNames(labels)
+	res.Fields = fieldValueMap(fieldValues)
+	if res.SlaState = slaState(bug, policy, time.Now().UTC()); res.SlaState != "" {
+		deadline, _ := slaDeadline(bug, policy)
+		res.SlaDeadline = &deadline
+	}
+	if viewer, role := viewerFromContext(r.Context()); canonical.ID != uuid.Nil && canViewBug(canonical, viewer, role) {
+		res.DuplicateOf = &LinkedBug{ID: canonical.ID, Key: canonical.Key, Title: canonical.Title, Status: canonical.Status}
+	}
 	logger.Info("response ready", "bug", bug)
-	utils.RespondWithJSON(w, http.StatusOK, bug)
+	utils.RespondWithJSON(w, http.StatusOK, res)
 }
 
 // @Summary Update an existing  bug
@@ -163,11 +722,12 @@ func (cfg *APIConfig) GetBugByIDHandler(w http.ResponseWriter, r *http.Request)
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param bugid path string true "Bug ID or key" example:"API-123"
 // @Param request body UpdateBugRequest true "bug updation data"
-// @Success 200 {object} database.Bug
+// @Success 200 {object} BugResponse
 // @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
 // @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
 // @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
 // @Router /bug/{bugid} [put]
 // @Security BearerAuth
@@ -193,13 +753,7 @@ func (cfg *APIConfig) UpdateBugHandler(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWithError(w, http.StatusBadRequest, " no bugID given ")
 		return
 	}
-	bugID, err := uuid.Parse(bugParam)
-	if err != nil {
-		logger.Error("given id format is wrong", "error", err)
-		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
-		return
-	}
-	logger = logger.With("bugId", bugID)
+	logger = logger.With("bugRef", bugParam)
 
 	var req UpdateBugRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -207,15 +761,35 @@ func (cfg *APIConfig) UpdateBugHandler(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	if req.Severity != nil && !validSeverities[*req.Severity] {
+		logger.Error("invalid severity", "severity", *req.Severity)
+		utils.RespondWithError(w, http.StatusBadRequest, "severity must be one of blocker, critical, major, minor, trivial")
+		return
+	}
+	if req.Priority != nil && !validPriorities[*req.Priority] {
+		logger.Error("invalid priority", "priority", *req.Priority)
+		utils.RespondWithError(w, http.StatusBadRequest, "priority must be one of P0, P1, P2, P3, P4")
+		return
+	}
 
-	bug, err := cfg.DB.GetBugsByID(r.Context(), bugID)
+	bug, err := cfg.getBugByRef(r.Context(), bugParam)
 	logger.Info("doing database operation")
+	if errors.Is(err, errInvalidBugRef) {
+		logger.Error("given id format is wrong", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "no bug found with the id "+bugParam)
+		return
+	}
 	if err != nil {
 		logger.Error("databse error bug not found in database", "error", err)
 		utils.RespondWithError(w, http.StatusInternalServerError, "no bug  found with the id")
 		return
 
 	}
+	bugID := bug.ID
 	logger = logger.With("bug", bug)
 	if userID != bug.PostedBy {
 		logger.Error("unauthorised to edit the bug, not owned by user", "error", err)
@@ -227,16 +801,89 @@ func (cfg *APIConfig) UpdateBugHandler(w http.ResponseWriter, r *http.Request) {
 		ID:          bugID,
 		Title:       toNullString(req.Title),
 		Description: toNullString(req.Description),
+		Severity:    toNullString(req.Severity),
+		Priority:    toNullString(req.Priority),
 	}
 	logger = logger.With("params", params)
 
-	err = cfg.DB.UpdateBugByID(r.Context(), params)
+	var changes []bugChange
+	if req.Title != nil {
+		changes = append(changes, bugChange{"title", textValue(bug.Title), textValue(*req.Title)})
+	}
+	if req.Description != nil {
+		changes = append(changes, bugChange{"description", textValue(bug.Description), textValue(*req.Description)})
+	}
+	if req.Severity != nil {
+		changes = append(changes, bugChange{"severity", textValue(bug.Severity), textValue(*req.Severity)})
+	}
+	if req.Priority != nil {
+		changes = append(changes, bugChange{"priority", textValue(bug.Priority), textValue(*req.Priority)})
+	}
+	fields, err := cfg.resolveFieldValues(r.Context(), bug.ProjectID, req.Fields)
+	if errors.Is(err, errInvalidFieldValue) {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		logger.Error("checking custom fields failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bug")
+		return
+	}
+	if len(fields) > 0 {
+		current, err := cfg.DB.GetBugFieldValues(r.Context(), bugID)
+		if err != nil {
+			logger.Error("fetching custom fields failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bug")
+			return
+		}
+		changes = append(changes, fieldHistory(current, fields)...)
+	}
+	var mentioned []database.GetMentionCandidatesRow
+	if req.Description != nil {
+		mentioned, err = cfg.resolveMentions(r.Context(), *req.Description)
+		if err != nil {
+			logger.Error("resolving mentions failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bug")
+			return
+		}
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bug")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	err = qtx.UpdateBugByID(r.Context(), params)
 	logger.Info("doing database updation")
 	if err != nil {
 		logger.Error("updating bug in databse failed", "error", err)
 		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bug")
 		return
 	}
+	if err := writeFieldValues(r.Context(), qtx, bugID, fields); err != nil {
+		logger.Error("storing custom fields failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bug")
+		return
+	}
+	if err := recordMentions(r.Context(), qtx, bugID, uuid.NullUUID{}, userID, mentioned); err != nil {
+		logger.Error("recording mentions failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bug")
+		return
+	}
+	if err := recordBugChanges(r.Context(), qtx, bugID, userID, changes); err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bug")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bug")
+		return
+	}
 	updatedbug, err := cfg.DB.GetBugsByID(r.Context(), bugID)
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch updated bug")
@@ -244,9 +891,195 @@ func (cfg *APIConfig) UpdateBugHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	logger = logger.With("updatedbug", updatedbug)
 
-	utils.RespondWithJSON(w, http.StatusOK, updatedbug)
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponse(updatedbug))
 	logger.Info("completed updation")
 }
+
+// @Summary Change the status of a bug
+// @Description The author of a bug or an admin can move it through the status workflow. Resolving or closing a bug needs a resolution: fixed, wont_fix, duplicate, cannot_reproduce or works_as_intended. Reopening clears it.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param request body TransitionBugRequest true "new status"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Illegal status transition"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/status [post]
+// @Security BearerAuth
+func (cfg *APIConfig) TransitionBugStatusHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "TransitionBugStatusHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	logger.Info("entered handler")
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		logger.Error("user id not given or invalid")
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	logger = logger.With("userID", userID)
+
+	bugID, err := uuid.Parse(r.PathValue("bugid"))
+	if err != nil {
+		logger.Error("given id format is wrong", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return
+	}
+	logger = logger.With("bugID", bugID)
+
+	var req TransitionBugRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("given request body in wrong format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if _, known := bugStatusTransitions[req.Status]; !known {
+		logger.Error("unknown status requested", "status", req.Status)
+		utils.RespondWithError(w, http.StatusBadRequest, "unknown status")
+		return
+	}
+	if req.Resolution != "" {
+		if !resolvedStatuses[req.Status] {
+			utils.RespondWithError(w, http.StatusBadRequest, "resolution can only be given when resolving or closing a bug")
+			return
+		}
+		if !validResolutions[req.Resolution] {
+			utils.RespondWithError(w, http.StatusBadRequest, "resolution must be one of fixed, wont_fix, duplicate, cannot_reproduce, works_as_intended")
+			return
+		}
+	}
+
+	bug, err := cfg.getBugByRef(r.Context(), bugID.String())
+	if err != nil {
+		logger.Error("bug not found in database", "error", err)
+		utils.RespondWithError(w, http.StatusNotFound, "no bug found with the id")
+		return
+	}
+	if !canManageBug(bug, userID, role) {
+		logger.Error("user cannot change status of this bug")
+		utils.RespondWithError(w, http.StatusForbidden, "only author, assignee or admin can change the status")
+		return
+	}
+	if !isValidTransition(bug.Status, req.Status) {
+		logger.Error("illegal status transition", "from", bug.Status, "to", req.Status)
+		utils.RespondWithError(w, http.StatusConflict, "cannot move bug from "+bug.Status+" to "+req.Status)
+		return
+	}
+	if resolvedStatuses[req.Status] && req.Resolution == "" && !bug.Resolution.Valid {
+		logger.Error("resolution missing", "to", req.Status)
+		utils.RespondWithError(w, http.StatusBadRequest, "a resolution is required to move a bug to "+req.Status)
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change status")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	changed, err := changeBugStatus(r.Context(), qtx, bug, req.Status, req.Resolution, userID)
+	if err != nil {
+		logger.Error("changing bug status failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change status")
+		return
+	}
+	if !changed {
+		logger.Error("bug status changed concurrently")
+		utils.RespondWithError(w, http.StatusConflict, "bug status was changed by someone else, retry")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change status")
+		return
+	}
+
+	updatedBug, err := cfg.DB.GetBugsByID(r.Context(), bugID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch updated bug")
+		return
+	}
+	logger.Info("status changed", "from", bug.Status, "to", req.Status)
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponse(updatedBug))
+}
+
+// changeBugStatus moves a bug to a new status on the given transaction and records the
+// transition in the bug's history. A resolved or closed bug keeps its resolution unless
+// a new one is given; any other status clears it, and reopening counts as a reopen. It
+// reports false, changing nothing, when the status was changed by someone else since
+// the bug was read.
+func changeBugStatus(ctx context.Context, q *database.Queries, bug database.Bug, to, resolution string, actorID uuid.UUID) (bool, error) {
+	newResolution := sql.NullString{}
+	if resolvedStatuses[to] {
+		newResolution = bug.Resolution
+		if resolution != "" {
+			newResolution = textValue(resolution)
+		}
+	}
+	updated, err := q.UpdateBugStatus(ctx, database.UpdateBugStatusParams{
+		ID:         bug.ID,
+		ToStatus:   to,
+		Resolution: newResolution,
+		FromStatus: bug.Status,
+	})
+	if err != nil || updated == 0 {
+		return false, err
+	}
+	_, err = q.CreateBugStatusTransition(ctx, database.CreateBugStatusTransitionParams{
+		BugID:      bug.ID,
+		FromStatus: bug.Status,
+		ToStatus:   to,
+		ChangedBy:  actorID,
+	})
+	if err != nil {
+		return false, err
+	}
+	err = recordBugChanges(ctx, q, bug.ID, actorID, []bugChange{
+		{"status", textValue(bug.Status), textValue(to)},
+		{"resolution", bug.Resolution, newResolution},
+	})
+	return err == nil, err
+}
+
+// @Summary List status transitions of a bug
+// @Description Returns who moved the bug between statuses and when, oldest first
+// @Tags bugs
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Success 200 {array} database.BugStatusTransition
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/transitions [get]
+func (cfg *APIConfig) GetBugTransitionsHandler(w http.ResponseWriter, r *http.Request) {
+	bugID, err := uuid.Parse(r.PathValue("bugid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return
+	}
+	if _, err := cfg.getBugByRef(r.Context(), bugID.String()); err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "no bug found with the id")
+		return
+	}
+	transitions, err := cfg.DB.GetBugStatusTransitions(r.Context(), bugID)
+	if err != nil {
+		slog.Error("fetching status transitions failed", "bugID", bugID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch transitions")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, transitions)
+}
+
 func toNullString(s *string) sql.NullString {
 	if s == nil {
 		return sql.NullString{Valid: false}
@@ -255,7 +1088,7 @@ func toNullString(s *string) sql.NullString {
 }
 
 // @Summary Delete an existing  user
-// @Description admin can delete bugs using their id
+// @Description admin can delete bugs using their id. Deleted bugs move to the trash and can be restored until they are purged
 // @Tags bugs
 // @Accept json
 // @Produce json
@@ -263,8 +1096,9 @@ func toNullString(s *string) sql.NullString {
 // @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
 // @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
 // @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
 // @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
-// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param bugid path string true "Bug ID or key" example:"API-123"
 // @Router /bugs/{bugid} [delete]
 // @Security BearerAuth
 func (cfg *APIConfig) DeleteBugByIDHandler(w http.ResponseWriter, r *http.Request) {
@@ -281,15 +1115,8 @@ func (cfg *APIConfig) DeleteBugByIDHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	logger = logger.With("userID", userID)
-	userVal := r.Context().Value("user")
-	user, ok := userVal.(database.User)
-	if !ok {
-		logger.Error("user data not found in contest")
-		utils.RespondWithError(w, http.StatusUnauthorized, "user not in context")
-		return
-	}
-
-	if user.Role != "admin" {
+	role, _ := r.Context().Value("role").(string)
+	if role != "admin" {
 		logger.Error("user has no admin status, cannot delete")
 		utils.RespondWithError(w, http.StatusForbidden, "admin access required")
 		return
@@ -301,35 +1128,46 @@ func (cfg *APIConfig) DeleteBugByIDHandler(w http.ResponseWriter, r *http.Reques
 		utils.RespondWithError(w, http.StatusBadRequest, "no id in the request")
 		return
 	}
-	bugID, err := uuid.Parse(bugParam)
-	if err != nil {
-		logger.Error("bugID in wrong format", "error", err)
-		utils.RespondWithError(w, http.StatusBadRequest, "wrong format id")
-		return
-	}
-	logger = logger.With("bugId", bugID)
+	logger = logger.With("bugRef", bugParam)
 	logger.Info("started querying to get existing bug with bugID")
-	bug, err := cfg.DB.GetBugsByID(r.Context(), bugID)
-	if err != nil {
-		logger.Error("database operation failed", "error", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "no bug  found with the id")
+	bug, ok := cfg.bugFromRef(w, r, logger, bugParam)
+	if !ok {
 		return
-
 	}
 	logger = logger.With("bug", bug)
-	if userID != bug.PostedBy {
-		logger.Error("user is not the author of the bug")
-		utils.RespondWithError(w, http.StatusUnauthorized, "only author can delete the bug")
+	logger.Info("starting databse operationto delete bug")
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete bug")
 		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
 
+	deletedAt, err := qtx.SoftDeleteBug(r.Context(), bug.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "bug not found")
+		return
 	}
-	logger.Info("starting databse operationto delete bug")
-	err = cfg.DB.DeleteBugByID(r.Context(), bugID)
 	if err != nil {
 		logger.Error("databse operation failed, cannot delete", "error", err)
 		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete bug")
 		return
 	}
+	err = recordBugChanges(r.Context(), qtx, bug.ID, userID, []bugChange{
+		{"deleted_at", sql.NullString{}, timeValue(deletedAt)},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete bug")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete bug")
+		return
+	}
 	logger.Info("completed handler ")
 	w.WriteHeader(http.StatusNoContent)
 }
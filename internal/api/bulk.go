@@ -0,0 +1,399 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// maxBulkBugs caps how many bugs one bulk edit may touch.
+const maxBulkBugs = 500
+
+const (
+	bulkUpdated   = "updated"
+	bulkForbidden = "forbidden"
+	bulkNotFound  = "not_found"
+	bulkInvalid   = "invalid"
+	bulkConflict  = "conflict"
+)
+
+// BulkBugFilter selects bugs the way the query string of GET /api/bugs does.
+type BulkBugFilter struct {
+	Project  string   `json:"project" example:"API"`
+	Severity []string `json:"severity"`
+	Priority []string `json:"priority"`
+	Label    []string `json:"label"`
+	Sla      string   `json:"sla" example:"breached"`
+	// Fields filters on custom field values, by field name.
+	Fields map[string]string `json:"fields"`
+}
+
+// BulkBugChanges lists what to change on every selected bug; empty fields are left alone.
+type BulkBugChanges struct {
+	Status     string `json:"status" example:"closed"`
+	Resolution string `json:"resolution" example:"wont_fix"`
+	// AssigneeID assigns the bugs to a user, Unassign removes their assignee.
+	AssigneeID   *uuid.UUID `json:"assignee_id" example:"9b733930-ef6f-4b01-add2-f410962ec695"`
+	Unassign     bool       `json:"unassign"`
+	AddLabels    []string   `json:"add_labels"`
+	RemoveLabels []string   `json:"remove_labels"`
+}
+
+func (c BulkBugChanges) isEmpty() bool {
+	return c.Status == "" && c.AssigneeID == nil && !c.Unassign && len(c.AddLabels) == 0 && len(c.RemoveLabels) == 0
+}
+
+// BulkUpdateBugsRequest selects bugs either by IDs, which may be UUIDs or keys, or by
+// Filter, never both.
+type BulkUpdateBugsRequest struct {
+	IDs     []string       `json:"ids"`
+	Filter  *BulkBugFilter `json:"filter"`
+	Changes BulkBugChanges `json:"changes"`
+}
+
+// BulkBugResult tells what happened to one bug. Result is one of updated, forbidden,
+// not_found, invalid or conflict.
+type BulkBugResult struct {
+	Ref    string     `json:"ref" example:"API-123"`
+	BugID  *uuid.UUID `json:"bug_id,omitempty"`
+	Result string     `json:"result" example:"updated"`
+	Error  string     `json:"error,omitempty"`
+}
+
+type BulkUpdateBugsResponse struct {
+	Updated int             `json:"updated"`
+	Failed  int             `json:"failed"`
+	Results []BulkBugResult `json:"results"`
+}
+
+// bulkTarget is a bug to change together with the index of its result.
+type bulkTarget struct {
+	index int
+	bug   database.Bug
+}
+
+// @Summary Edit many bugs at once
+// @Description Applies the same changes to a list of bugs or to every bug matching a filter, at most 500, in one transaction.
+// @Description Each bug is checked on its own: the author, the assignee or an admin may change it. Bugs that cannot be changed are reported in the results and do not stop the others.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Param request body BulkUpdateBugsRequest true "bugs and changes"
+// @Success 200 {object} BulkUpdateBugsResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/bulk [post]
+// @Security BearerAuth
+func (cfg *APIConfig) BulkUpdateBugsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "BulkUpdateBugsHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		logger.Error("user id not given or invalid")
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	logger = logger.With("userID", userID)
+
+	var req BulkUpdateBugsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("given request body in wrong format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if (len(req.IDs) > 0) == (req.Filter != nil) {
+		utils.RespondWithError(w, http.StatusBadRequest, "give either ids or filter")
+		return
+	}
+	if len(req.IDs) > maxBulkBugs {
+		utils.RespondWithError(w, http.StatusBadRequest, "at most 500 bugs can be edited at once")
+		return
+	}
+	changes := req.Changes
+	if changes.isEmpty() {
+		utils.RespondWithError(w, http.StatusBadRequest, "no changes given")
+		return
+	}
+	if changes.Status != "" {
+		if _, known := bugStatusTransitions[changes.Status]; !known {
+			utils.RespondWithError(w, http.StatusBadRequest, "unknown status")
+			return
+		}
+	}
+	if changes.Resolution != "" {
+		if !resolvedStatuses[changes.Status] {
+			utils.RespondWithError(w, http.StatusBadRequest, "resolution can only be given when resolving or closing bugs")
+			return
+		}
+		if !validResolutions[changes.Resolution] {
+			utils.RespondWithError(w, http.StatusBadRequest, "resolution must be one of fixed, wont_fix, duplicate, cannot_reproduce, works_as_intended")
+			return
+		}
+	}
+	if changes.AssigneeID != nil && changes.Unassign {
+		utils.RespondWithError(w, http.StatusBadRequest, "give either assignee_id or unassign")
+		return
+	}
+	if changes.AssigneeID != nil {
+		if _, err := cfg.DB.GetUserByID(r.Context(), *changes.AssigneeID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				utils.RespondWithError(w, http.StatusNotFound, "assignee does not exist")
+				return
+			}
+			logger.Error("cannot fetch assignee", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch assignee")
+			return
+		}
+	}
+	addLabels, ok := cfg.labelsByName(w, r, logger, changes.AddLabels)
+	if !ok {
+		return
+	}
+	removeLabels, ok := cfg.labelsByName(w, r, logger, changes.RemoveLabels)
+	if !ok {
+		return
+	}
+
+	var results []BulkBugResult
+	var targets []bulkTarget
+	if req.Filter != nil {
+		results, targets, ok = cfg.bulkTargetsByFilter(w, r, logger, *req.Filter)
+	} else {
+		results, targets, ok = cfg.bulkTargetsByRef(w, r, logger, req.IDs)
+	}
+	if !ok {
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bugs")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	for _, target := range targets {
+		result := &results[target.index]
+		result.Result, result.Error, err = applyBulkChanges(r.Context(), qtx, target.bug, changes, addLabels, removeLabels, userID, role)
+		if err != nil {
+			logger.Error("bulk update failed", "bugID", target.bug.ID, "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bugs")
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bugs")
+		return
+	}
+
+	res := BulkUpdateBugsResponse{Results: results}
+	for _, result := range results {
+		if result.Result == bulkUpdated {
+			res.Updated++
+		} else {
+			res.Failed++
+		}
+	}
+	logger.Info("bugs updated in bulk", "updated", res.Updated, "failed", res.Failed)
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// labelsByName loads the named labels. It writes the error response itself when it
+// returns false.
+func (cfg *APIConfig) labelsByName(w http.ResponseWriter, r *http.Request, logger *slog.Logger, names []string) ([]database.Label, bool) {
+	labels := make([]database.Label, 0, len(names))
+	for _, name := range names {
+		label, err := cfg.DB.GetLabelByName(r.Context(), name)
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusBadRequest, "unknown label: "+name)
+			return nil, false
+		}
+		if err != nil {
+			logger.Error("fetching label failed", "label", name, "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bugs")
+			return nil, false
+		}
+		labels = append(labels, label)
+	}
+	return labels, true
+}
+
+// bulkTargetsByRef looks up the bugs named by UUID or key. Refs that name no bug, or a
+// bug named before, get their result right away. It writes the error response itself
+// when it returns false.
+func (cfg *APIConfig) bulkTargetsByRef(w http.ResponseWriter, r *http.Request, logger *slog.Logger, refs []string) ([]BulkBugResult, []bulkTarget, bool) {
+	results := make([]BulkBugResult, len(refs))
+	targets := make([]bulkTarget, 0, len(refs))
+	seen := make(map[uuid.UUID]bool, len(refs))
+	for i, ref := range refs {
+		results[i].Ref = ref
+		bug, err := cfg.getBugByRef(r.Context(), ref)
+		switch {
+		case errors.Is(err, errInvalidBugRef):
+			results[i].Result, results[i].Error = bulkInvalid, err.Error()
+			continue
+		case errors.Is(err, sql.ErrNoRows):
+			results[i].Result, results[i].Error = bulkNotFound, "no bug found with the id"
+			continue
+		case err != nil:
+			logger.Error("fetching bug failed", "ref", ref, "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bugs")
+			return nil, nil, false
+		}
+		results[i].BugID = &bug.ID
+		if seen[bug.ID] {
+			results[i].Result, results[i].Error = bulkInvalid, "bug is listed more than once"
+			continue
+		}
+		seen[bug.ID] = true
+		targets = append(targets, bulkTarget{index: i, bug: bug})
+	}
+	return results, targets, true
+}
+
+// bulkTargetsByFilter lists the bugs matching a filter. It writes the error response
+// itself when it returns false.
+func (cfg *APIConfig) bulkTargetsByFilter(w http.ResponseWriter, r *http.Request, logger *slog.Logger, filter BulkBugFilter) ([]BulkBugResult, []bulkTarget, bool) {
+	projectID := uuid.NullUUID{}
+	if filter.Project != "" {
+		project, err := cfg.DB.GetProjectByKey(r.Context(), filter.Project)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "unknown project: "+filter.Project)
+			return nil, nil, false
+		}
+		projectID = uuid.NullUUID{UUID: project.ID, Valid: true}
+	}
+	params, err := listBugsParams(filter.query(), projectID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return nil, nil, false
+	}
+	viewer, role := viewerFromContext(r.Context())
+	params.ViewerID, params.SeeConfidential = viewer, seesConfidentialBugs(role)
+	bugs, err := cfg.DB.ListBugs(r.Context(), params)
+	if err != nil {
+		logger.Error("listing bugs failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update bugs")
+		return nil, nil, false
+	}
+	if len(bugs) > maxBulkBugs {
+		utils.RespondWithError(w, http.StatusBadRequest, "filter matches more than 500 bugs, narrow it down")
+		return nil, nil, false
+	}
+	results := make([]BulkBugResult, len(bugs))
+	targets := make([]bulkTarget, len(bugs))
+	for i, bug := range bugs {
+		results[i] = BulkBugResult{Ref: bug.Key, BugID: &bug.ID}
+		targets[i] = bulkTarget{index: i, bug: bug}
+	}
+	return results, targets, true
+}
+
+// query spells the filter the way listBugsParams reads it.
+func (f BulkBugFilter) query() url.Values {
+	query := url.Values{
+		"severity": f.Severity,
+		"priority": f.Priority,
+		"label":    f.Label,
+	}
+	if f.Sla != "" {
+		query.Set("sla", f.Sla)
+	}
+	for name, value := range f.Fields {
+		query.Set("field."+name, value)
+	}
+	return query
+}
+
+// applyBulkChanges applies the changes to one bug on the given transaction and records
+// them in its history. It returns the bug's result and, for bugs it leaves alone, why;
+// an error means the transaction cannot go on.
+func applyBulkChanges(ctx context.Context, q *database.Queries, bug database.Bug, changes BulkBugChanges, addLabels, removeLabels []database.Label, actorID uuid.UUID, role string) (string, string, error) {
+	if !canManageBug(bug, actorID, role) {
+		return bulkForbidden, "only author, assignee or admin can change this bug", nil
+	}
+	// The resolution is only set on the way into a resolved status, so on a bug that is
+	// already there it would be dropped.
+	if changes.Resolution != "" && changes.Status == bug.Status && bug.Resolution.String != changes.Resolution {
+		return bulkInvalid, "bug is already " + bug.Status + ", its resolution cannot be changed", nil
+	}
+	if changes.Status != "" && changes.Status != bug.Status {
+		if !isValidTransition(bug.Status, changes.Status) {
+			return bulkConflict, "cannot move bug from " + bug.Status + " to " + changes.Status, nil
+		}
+		if resolvedStatuses[changes.Status] && changes.Resolution == "" && !bug.Resolution.Valid {
+			return bulkInvalid, "a resolution is required to move a bug to " + changes.Status, nil
+		}
+		changed, err := changeBugStatus(ctx, q, bug, changes.Status, changes.Resolution, actorID)
+		if err != nil {
+			return "", "", err
+		}
+		if !changed {
+			return bulkConflict, "bug status was changed by someone else", nil
+		}
+	}
+	if changes.AssigneeID != nil || changes.Unassign {
+		assignee := uuid.NullUUID{}
+		if changes.AssigneeID != nil {
+			assignee = uuid.NullUUID{UUID: *changes.AssigneeID, Valid: true}
+		}
+		if assignee != bug.AssigneeID {
+			err := q.SetBugAssignee(ctx, database.SetBugAssigneeParams{ID: bug.ID, AssigneeID: assignee})
+			if err != nil {
+				return "", "", err
+			}
+			err = recordBugChanges(ctx, q, bug.ID, actorID, []bugChange{
+				{"assignee_id", uuidValue(bug.AssigneeID), uuidValue(assignee)},
+			})
+			if err != nil {
+				return "", "", err
+			}
+			if assignee.Valid {
+				err = q.AddBugWatcher(ctx, database.AddBugWatcherParams{BugID: bug.ID, UserID: assignee.UUID})
+				if err != nil {
+					return "", "", err
+				}
+			}
+		}
+	}
+	var labelChanges []bugChange
+	for _, label := range addLabels {
+		added, err := q.AddLabelToBug(ctx, database.AddLabelToBugParams{BugID: bug.ID, LabelID: label.ID})
+		if err != nil {
+			return "", "", err
+		}
+		if added > 0 {
+			labelChanges = append(labelChanges, bugChange{"labels", sql.NullString{}, textValue(label.Name)})
+		}
+	}
+	for _, label := range removeLabels {
+		removed, err := q.RemoveLabelFromBug(ctx, database.RemoveLabelFromBugParams{BugID: bug.ID, LabelID: label.ID})
+		if err != nil {
+			return "", "", err
+		}
+		if removed > 0 {
+			labelChanges = append(labelChanges, bugChange{"labels", textValue(label.Name), sql.NullString{}})
+		}
+	}
+	if err := recordBugChanges(ctx, q, bug.ID, actorID, labelChanges); err != nil {
+		return "", "", err
+	}
+	return bulkUpdated, "", nil
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkUpdateBugsHandlerReportsPerBugResults(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	ownBugID := uuid.New()
+	otherBugID := uuid.New()
+	labelID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetLabelByName :one`)).WithArgs("triaged").
+		WillReturnRows(sqlmock.NewRows(labelColumns).AddRow(labelID, "triaged", "#00ff00", "", time.Now(), time.Now()))
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(ownBugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(ownBugID, "typo on login page", "", userID, time.Now(), time.Now(), "open", "minor", "P3", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(otherBugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(otherBugID, "typo in footer", "", uuid.New(), time.Now(), time.Now(), "open", "minor", "P3", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-9").
+		WillReturnRows(sqlmock.NewRows(bugColumns))
+	mock.ExpectBegin()
+	mock.ExpectExec(updateBugStatusQuery).
+		WithArgs(ownBugID, "closed", "wont_fix", "open").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bug_status_transitions`)).
+		WithArgs(ownBugID, "open", "closed", userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bug_id", "from_status", "to_status", "changed_by", "changed_at"}).
+			AddRow(uuid.New(), ownBugID, "open", "closed", userID, time.Now()))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(ownBugID, userID, "status", "open", "closed").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(ownBugID, userID, "resolution", nil, "wont_fix").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddLabelToBug :execrows`)).WithArgs(ownBugID, labelID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(ownBugID, userID, "labels", nil, "triaged").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := `{"ids":["` + ownBugID.String() + `","` + otherBugID.String() + `","BUG-9","nope"],` +
+		`"changes":{"status":"closed","resolution":"wont_fix","add_labels":["triaged"]}}`
+	req := httptest.NewRequest("POST", "/api/bugs/bulk", bytes.NewBufferString(body))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	cfg.BulkUpdateBugsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BulkUpdateBugsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, 1, response.Updated)
+	assert.Equal(t, 3, response.Failed)
+	if assert.Len(t, response.Results, 4) {
+		assert.Equal(t, "updated", response.Results[0].Result)
+		assert.Equal(t, "forbidden", response.Results[1].Result)
+		assert.Equal(t, "not_found", response.Results[2].Result)
+		assert.Equal(t, "invalid", response.Results[3].Result)
+		assert.Equal(t, "nope", response.Results[3].Ref)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkUpdateBugsHandlerRejectsResolutionWithoutStatusChange(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "typo on login page", "", userID, time.Now(), time.Now(), "closed", "minor", "P3", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, "fixed", 0, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	body := `{"ids":["` + bugID.String() + `"],"changes":{"status":"closed","resolution":"wont_fix"}}`
+	req := httptest.NewRequest("POST", "/api/bugs/bulk", bytes.NewBufferString(body))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	cfg.BulkUpdateBugsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BulkUpdateBugsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, 0, response.Updated)
+	if assert.Len(t, response.Results, 1) {
+		assert.Equal(t, "invalid", response.Results[0].Result)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkUpdateBugsHandlerByFilter(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	assigneeID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetUserByID :one`)).WithArgs(assigneeID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "email", "hashed_password", "role"}).
+			AddRow(assigneeID, time.Now(), time.Now(), "dev@example.com", "hash", "user"))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: ListBugs :many`)).
+		WithArgs(nil, "{\"P0\"}", nil, nil, nil, nil, nil, false, userID, "created").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "checkout fails", "", userID, time.Now(), time.Now(), "triaged", "critical", "P0", nil, testProjectID, 5, "BUG-5", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: SetBugAssignee :exec`)).WithArgs(bugID, assigneeID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "assignee_id", nil, assigneeID.String()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddBugWatcher :exec`)).WithArgs(bugID, assigneeID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := `{"filter":{"priority":["P0"]},"changes":{"assignee_id":"` + assigneeID.String() + `"}}`
+	req := httptest.NewRequest("POST", "/api/bugs/bulk", bytes.NewBufferString(body))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	cfg.BulkUpdateBugsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BulkUpdateBugsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, 1, response.Updated)
+	if assert.Len(t, response.Results, 1) {
+		assert.Equal(t, "BUG-5", response.Results[0].Ref)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkUpdateBugsHandlerRejectsBadRequests(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	for _, body := range []string{
+		`{"ids":["BUG-1"],"filter":{"priority":["P0"]},"changes":{"status":"closed","resolution":"fixed"}}`,
+		`{"changes":{"status":"closed","resolution":"fixed"}}`,
+		`{"ids":["BUG-1"],"changes":{}}`,
+		`{"ids":["BUG-1"],"changes":{"status":"done"}}`,
+		`{"ids":["BUG-1"],"changes":{"status":"triaged","resolution":"fixed"}}`,
+		`{"filter":{"severity":["catastrophic"]},"changes":{"unassign":true}}`,
+	} {
+		req := httptest.NewRequest("POST", "/api/bugs/bulk", bytes.NewBufferString(body))
+		req = req.WithContext(context.WithValue(req.Context(), "userID", uuid.New()))
+		w := httptest.NewRecorder()
+		cfg.BulkUpdateBugsHandler(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,367 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type CreateCommentRequest struct {
+	Body     string     `json:"body" example:"Reproduced on staging as well"`
+	ParentID *uuid.UUID `json:"parent_id" example:"3f1c2b7e-0a53-4c8e-9d7a-0c0f4c8a8b11"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" example:"Reproduced on staging and prod"`
+}
+
+type CommentResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	BugID     uuid.UUID         `json:"bug_id"`
+	AuthorID  uuid.UUID         `json:"author_id"`
+	ParentID  *uuid.UUID        `json:"parent_id"`
+	Body      string            `json:"body"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	EditedAt  *time.Time        `json:"edited_at"`
+	Replies   []CommentResponse `json:"replies,omitempty"`
+}
+
+func toCommentResponse(comment database.Comment) CommentResponse {
+	res := CommentResponse{
+		ID:        comment.ID,
+		BugID:     comment.BugID,
+		AuthorID:  comment.AuthorID,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+	if comment.ParentID.Valid {
+		res.ParentID = &comment.ParentID.UUID
+	}
+	if comment.EditedAt.Valid {
+		res.EditedAt = &comment.EditedAt.Time
+	}
+	return res
+}
+
+// buildCommentThreads nests replies under their parents. Comments are expected in
+// creation order, so every thread and every reply list stays oldest first.
+func buildCommentThreads(comments []database.Comment) []CommentResponse {
+	children := make(map[uuid.UUID][]database.Comment)
+	var roots []database.Comment
+	for _, comment := range comments {
+		if comment.ParentID.Valid {
+			children[comment.ParentID.UUID] = append(children[comment.ParentID.UUID], comment)
+			continue
+		}
+		roots = append(roots, comment)
+	}
+
+	var build func(comment database.Comment) CommentResponse
+	build = func(comment database.Comment) CommentResponse {
+		res := toCommentResponse(comment)
+		for _, reply := range children[comment.ID] {
+			res.Replies = append(res.Replies, build(reply))
+		}
+		return res
+	}
+
+	threads := make([]CommentResponse, 0, len(roots))
+	for _, root := range roots {
+		threads = append(threads, build(root))
+	}
+	return threads
+}
+
+// @Summary Comment on a bug
+// @Description Logged in users can comment on a bug or reply to an existing comment. Mentioned users (@handle or @email) start watching the bug
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param request body CreateCommentRequest true "comment data"
+// @Success 201 {object} CommentResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/comments [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateCommentHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		logger.Error("user id missing in context")
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	logger = logger.With("userID", userID)
+
+	bugID, err := uuid.Parse(r.PathValue("bugid"))
+	if err != nil {
+		logger.Error("invalid id format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return
+	}
+	logger = logger.With("bugID", bugID)
+
+	var req CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "body field required")
+		return
+	}
+
+	if _, err := cfg.getBugByRef(r.Context(), bugID.String()); err != nil {
+		logger.Error("bug not found in database", "error", err)
+		utils.RespondWithError(w, http.StatusNotFound, "no bug found with the id")
+		return
+	}
+
+	parentID := uuid.NullUUID{}
+	if req.ParentID != nil {
+		parent, err := cfg.DB.GetCommentByID(r.Context(), *req.ParentID)
+		if err != nil || parent.BugID != bugID {
+			logger.Error("parent comment not found on this bug", "parentID", *req.ParentID, "error", err)
+			utils.RespondWithError(w, http.StatusBadRequest, "parent comment does not belong to this bug")
+			return
+		}
+		parentID = uuid.NullUUID{UUID: parent.ID, Valid: true}
+	}
+
+	mentioned, err := cfg.resolveMentions(r.Context(), req.Body)
+	if err != nil {
+		logger.Error("resolving mentions failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create comment")
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create comment")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	comment, err := qtx.CreateComment(r.Context(), database.CreateCommentParams{
+		BugID:    bugID,
+		AuthorID: userID,
+		ParentID: parentID,
+		Body:     req.Body,
+	})
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create comment")
+		return
+	}
+	commentID := uuid.NullUUID{UUID: comment.ID, Valid: true}
+	if err := recordMentions(r.Context(), qtx, bugID, commentID, userID, mentioned); err != nil {
+		logger.Error("recording mentions failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create comment")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create comment")
+		return
+	}
+	logger.Info("comment created", "commentID", comment.ID)
+	utils.RespondWithJSON(w, http.StatusCreated, toCommentResponse(comment))
+}
+
+// @Summary List comments of a bug
+// @Description Returns the discussion on a bug as threads, oldest first
+// @Tags comments
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Success 200 {array} CommentResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/comments [get]
+func (cfg *APIConfig) GetCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	bugID, err := uuid.Parse(r.PathValue("bugid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return
+	}
+	if _, err := cfg.getBugByRef(r.Context(), bugID.String()); err != nil {
+		utils.RespondWithError(w, http.StatusNotFound, "no bug found with the id")
+		return
+	}
+	comments, err := cfg.DB.GetCommentsByBug(r.Context(), bugID)
+	if err != nil {
+		slog.Error("fetching comments failed", "bugID", bugID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch comments")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, buildCommentThreads(comments))
+}
+
+// @Summary Edit a comment
+// @Description The author of a comment can change its body, which marks it as edited
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param commentid path string true "Comment ID" example:"3f1c2b7e-0a53-4c8e-9d7a-0c0f4c8a8b11"
+// @Param request body UpdateCommentRequest true "comment updation data"
+// @Success 200 {object} CommentResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/comments/{commentid} [put]
+// @Security BearerAuth
+func (cfg *APIConfig) UpdateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UpdateCommentHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	comment, ok := cfg.commentOwnedByUser(w, r, logger)
+	if !ok {
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "body field required")
+		return
+	}
+
+	mentioned, err := cfg.resolveMentions(r.Context(), req.Body)
+	if err != nil {
+		logger.Error("resolving mentions failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update comment")
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update comment")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	updated, err := qtx.UpdateComment(r.Context(), database.UpdateCommentParams{
+		ID:   comment.ID,
+		Body: req.Body,
+	})
+	if err != nil {
+		logger.Error("updating comment failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update comment")
+		return
+	}
+	commentID := uuid.NullUUID{UUID: comment.ID, Valid: true}
+	if err := recordMentions(r.Context(), qtx, comment.BugID, commentID, comment.AuthorID, mentioned); err != nil {
+		logger.Error("recording mentions failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update comment")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update comment")
+		return
+	}
+	logger.Info("comment updated", "commentID", comment.ID)
+	utils.RespondWithJSON(w, http.StatusOK, toCommentResponse(updated))
+}
+
+// @Summary Delete a comment
+// @Description The author of a comment can delete it. Replies to it, possibly by other users, are kept as top-level comments
+// @Tags comments
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param commentid path string true "Comment ID" example:"3f1c2b7e-0a53-4c8e-9d7a-0c0f4c8a8b11"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/comments/{commentid} [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) DeleteCommentHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "DeleteCommentHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	comment, ok := cfg.commentOwnedByUser(w, r, logger)
+	if !ok {
+		return
+	}
+	if err := cfg.DB.DeleteComment(r.Context(), comment.ID); err != nil {
+		logger.Error("deleting comment failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete comment")
+		return
+	}
+	logger.Info("comment deleted", "commentID", comment.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// commentOwnedByUser loads the comment addressed by the request path and makes sure
+// the logged in user wrote it. It writes the error response itself when it returns false.
+func (cfg *APIConfig) commentOwnedByUser(w http.ResponseWriter, r *http.Request, logger *slog.Logger) (database.Comment, bool) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		logger.Error("user id missing in context")
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return database.Comment{}, false
+	}
+	bugID, err := uuid.Parse(r.PathValue("bugid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return database.Comment{}, false
+	}
+	commentID, err := uuid.Parse(r.PathValue("commentid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format comment Id")
+		return database.Comment{}, false
+	}
+
+	comment, err := cfg.DB.GetCommentByID(r.Context(), commentID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && comment.BugID != bugID) {
+		utils.RespondWithError(w, http.StatusNotFound, "no comment found with the id")
+		return database.Comment{}, false
+	}
+	if err != nil {
+		logger.Error("fetching comment failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch comment")
+		return database.Comment{}, false
+	}
+	if comment.AuthorID != userID {
+		logger.Error("user is not the author of the comment", "userID", userID, "commentID", commentID)
+		utils.RespondWithError(w, http.StatusForbidden, "only author can change the comment")
+		return database.Comment{}, false
+	}
+	return comment, true
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var commentColumns = []string{"id", "bug_id", "author_id", "parent_id", "body", "created_at", "updated_at", "edited_at"}
+
+func TestCreateCommentHandler(t *testing.T) {
+	logger := slog.Default().With(
+		"test", "TestCreateCommentHandler",
+	)
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	parentID := uuid.New()
+	commentID := uuid.New()
+	logger = logger.With("bugID", bugID)
+
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCommentByID :one`)).WithArgs(parentID).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(parentID, bugID, uuid.New(), nil, "cannot reproduce", time.Now(), time.Now(), nil))
+	expectedQuery := `-- name: CreateComment :one
+INSERT INTO comments (id, bug_id, author_id, parent_id, body, created_at, updated_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW(),
+    NOW()
+)
+RETURNING id, bug_id, author_id, parent_id, body, created_at, updated_at, edited_at`
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+		WithArgs(bugID, userID, parentID, "happens on every login").
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(commentID, bugID, userID, parentID, "happens on every login", time.Now(), time.Now(), nil))
+	mock.ExpectCommit()
+
+	requestBody, err := json.Marshal(CreateCommentRequest{
+		Body:     "happens on every login",
+		ParentID: &parentID,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request body: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/comments", cfg.CreateCommentHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/comments", bytes.NewBuffer(requestBody))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response CommentResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, commentID, response.ID)
+	if assert.NotNil(t, response.ParentID) {
+		assert.Equal(t, parentID, *response.ParentID)
+	}
+	assert.Nil(t, response.EditedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	logger.Info("test ended")
+}
+
+func TestGetCommentsHandlerNestsReplies(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	rootID := uuid.New()
+	replyID := uuid.New()
+	otherID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, bug_id, author_id, parent_id, body, created_at, updated_at, edited_at FROM comments WHERE bug_id = $1`)).
+		WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(rootID, bugID, uuid.New(), nil, "first", time.Now(), time.Now(), nil).
+			AddRow(replyID, bugID, uuid.New(), rootID, "reply", time.Now(), time.Now(), time.Now()).
+			AddRow(otherID, bugID, uuid.New(), nil, "second", time.Now(), time.Now(), nil))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/comments", cfg.GetCommentsHandler)
+	req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String()+"/comments", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []CommentResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Len(t, response, 2)
+	assert.Equal(t, rootID, response[0].ID)
+	if assert.Len(t, response[0].Replies, 1) {
+		assert.Equal(t, replyID, response[0].Replies[0].ID)
+		assert.NotNil(t, response[0].Replies[0].EditedAt)
+	}
+	assert.Equal(t, otherID, response[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateCommentHandlerMarksEdited(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	commentID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCommentByID :one`)).WithArgs(commentID).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(commentID, bugID, userID, nil, "typo", time.Now(), time.Now(), nil))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE comments SET body = $2, updated_at = NOW(), edited_at = NOW() WHERE id = $1`)).
+		WithArgs(commentID, "fixed typo").
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(commentID, bugID, userID, nil, "fixed typo", time.Now(), time.Now(), time.Now()))
+	mock.ExpectCommit()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/comments/{commentid}", cfg.UpdateCommentHandler)
+	req := httptest.NewRequest("PUT", "/api/bugs/"+bugID.String()+"/comments/"+commentID.String(), bytes.NewBufferString(`{"body":"fixed typo"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response CommentResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, "fixed typo", response.Body)
+	assert.NotNil(t, response.EditedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCommentHandlerOnlyAuthor(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	commentID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCommentByID :one`)).WithArgs(commentID).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(commentID, bugID, uuid.New(), nil, "not yours", time.Now(), time.Now(), nil))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/comments/{commentid}", cfg.DeleteCommentHandler)
+	req := httptest.NewRequest("DELETE", "/api/bugs/"+bugID.String()+"/comments/"+commentID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", uuid.New()))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCommentHandlerKeepsOtherUsersReplies(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	parentID := uuid.New()
+	replyID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCommentByID :one`)).WithArgs(parentID).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(parentID, bugID, userID, nil, "cannot reproduce", time.Now(), time.Now(), nil))
+	// only the parent row is deleted, the database detaches the reply from it
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: DeleteComment :exec`)).WithArgs(parentID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCommentsByBug :many`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(replyID, bugID, uuid.New(), nil, "happens on every login", time.Now(), time.Now(), nil))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /api/bugs/{bugid}/comments/{commentid}", cfg.DeleteCommentHandler)
+	mux.HandleFunc("GET /api/bugs/{bugid}/comments", cfg.GetCommentsHandler)
+	req := httptest.NewRequest("DELETE", "/api/bugs/"+bugID.String()+"/comments/"+parentID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/bugs/"+bugID.String()+"/comments", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []CommentResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.Len(t, response, 1) {
+		assert.Equal(t, replyID, response[0].ID)
+		assert.Nil(t, response[0].ParentID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,376 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type CreateComponentRequest struct {
+	Name        string    `json:"name" example:"auth"`
+	Description string    `json:"description" example:"Login, sessions and tokens"`
+	OwnerID     uuid.UUID `json:"owner_id" example:"9b733930-ef6f-4b01-add2-f410962ec695"`
+}
+
+type UpdateComponentRequest struct {
+	Name        *string    `json:"name" example:"auth"`
+	Description *string    `json:"description" example:"Login, sessions and tokens"`
+	OwnerID     *uuid.UUID `json:"owner_id" example:"9b733930-ef6f-4b01-add2-f410962ec695"`
+}
+
+type SetBugComponentRequest struct {
+	Component string `json:"component" example:"auth"`
+}
+
+type ComponentResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	Name        string     `json:"name" example:"auth"`
+	Description string     `json:"description"`
+	OwnerID     *uuid.UUID `json:"owner_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func toComponentResponse(component database.Component) ComponentResponse {
+	res := ComponentResponse{
+		ID:          component.ID,
+		ProjectID:   component.ProjectID,
+		Name:        component.Name,
+		Description: component.Description,
+		CreatedAt:   component.CreatedAt,
+		UpdatedAt:   component.UpdatedAt,
+	}
+	if component.OwnerID.Valid {
+		res.OwnerID = &component.OwnerID.UUID
+	}
+	return res
+}
+
+// @Summary Create a component
+// @Description The project owner or an admin can add a component. New bugs filed against it without an assignee go to its owner.
+// @Tags components
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Param request body CreateComponentRequest true "component creation data"
+// @Success 201 {object} ComponentResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Component name already taken"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/components [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateComponentHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateComponentHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	if !canManageProject(project, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only project owner or admin can add components")
+		return
+	}
+	var req CreateComponentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "name field required")
+		return
+	}
+	if req.OwnerID == uuid.Nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "owner_id field required")
+		return
+	}
+	if _, err := cfg.DB.GetUserByID(r.Context(), req.OwnerID); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "owner does not exist")
+		return
+	}
+
+	component, err := cfg.DB.CreateComponent(r.Context(), database.CreateComponentParams{
+		ProjectID:   project.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		OwnerID:     uuid.NullUUID{UUID: req.OwnerID, Valid: true},
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "component name already taken in this project")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create component")
+		return
+	}
+	logger.Info("component created", "componentID", component.ID, "project", project.Key)
+	utils.RespondWithJSON(w, http.StatusCreated, toComponentResponse(component))
+}
+
+// @Summary List components of a project
+// @Tags components
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Success 200 {array} ComponentResponse
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/components [get]
+func (cfg *APIConfig) GetProjectComponentsHandler(w http.ResponseWriter, r *http.Request) {
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	components, err := cfg.DB.GetComponentsByProject(r.Context(), project.ID)
+	if err != nil {
+		slog.Error("fetching components failed", "project", project.Key, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch components")
+		return
+	}
+	res := make([]ComponentResponse, 0, len(components))
+	for _, component := range components {
+		res = append(res, toComponentResponse(component))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Update a component
+// @Description The project owner or an admin can rename a component or hand it to a new owner. Bugs already filed keep their assignee.
+// @Tags components
+// @Accept json
+// @Produce json
+// @Param id path string true "Component ID" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param request body UpdateComponentRequest true "component updation data"
+// @Success 200 {object} ComponentResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Component name already taken"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /components/{id} [put]
+// @Security BearerAuth
+func (cfg *APIConfig) UpdateComponentHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UpdateComponentHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	componentID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format component Id")
+		return
+	}
+	component, err := cfg.DB.GetComponentByID(r.Context(), componentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "no component found with the id")
+		return
+	}
+	if err != nil {
+		logger.Error("fetching component failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch component")
+		return
+	}
+	project, err := cfg.DB.GetProjectByID(r.Context(), component.ProjectID)
+	if err != nil {
+		logger.Error("fetching project failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch component")
+		return
+	}
+	if !canManageProject(project, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only project owner or admin can update components")
+		return
+	}
+
+	var req UpdateComponentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			utils.RespondWithError(w, http.StatusBadRequest, "name cannot be empty")
+			return
+		}
+		req.Name = &name
+	}
+	ownerID := uuid.NullUUID{}
+	if req.OwnerID != nil {
+		if _, err := cfg.DB.GetUserByID(r.Context(), *req.OwnerID); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "new owner does not exist")
+			return
+		}
+		ownerID = uuid.NullUUID{UUID: *req.OwnerID, Valid: true}
+	}
+
+	updated, err := cfg.DB.UpdateComponent(r.Context(), database.UpdateComponentParams{
+		ID:          component.ID,
+		Name:        toNullString(req.Name),
+		Description: toNullString(req.Description),
+		OwnerID:     ownerID,
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "component name already taken in this project")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update component")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toComponentResponse(updated))
+}
+
+// @Summary Move a bug to a component
+// @Description The author, the assignee or an admin can file the bug under a component of its project. The assignee does not change.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param request body SetBugComponentRequest true "component name"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/component [put]
+// @Security BearerAuth
+func (cfg *APIConfig) SetBugComponentHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "SetBugComponentHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	var req SetBugComponentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("given request body in wrong format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Component == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "component field required")
+		return
+	}
+	cfg.setBugComponent(w, r, logger, req.Component)
+}
+
+// @Summary Take a bug out of its component
+// @Description The author, the assignee or an admin can remove the bug from its component
+// @Tags bugs
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/component [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) ClearBugComponentHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "ClearBugComponentHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	cfg.setBugComponent(w, r, logger, "")
+}
+
+// setBugComponent files the bug under the named component of its project, or under none
+// when name is empty.
+func (cfg *APIConfig) setBugComponent(w http.ResponseWriter, r *http.Request, logger *slog.Logger, name string) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	if !canManageBug(bug, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only author, assignee or admin can change the component")
+		return
+	}
+	componentID := uuid.NullUUID{}
+	if name != "" {
+		component, err := cfg.DB.GetComponentByName(r.Context(), database.GetComponentByNameParams{
+			ProjectID: bug.ProjectID,
+			Name:      name,
+		})
+		if err != nil {
+			utils.RespondWithError(w, http.StatusNotFound, "no component "+name+" in the bug's project")
+			return
+		}
+		componentID = uuid.NullUUID{UUID: component.ID, Valid: true}
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change component")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	err = qtx.SetBugComponent(r.Context(), database.SetBugComponentParams{
+		ID:          bug.ID,
+		ComponentID: componentID,
+	})
+	if err != nil {
+		logger.Error("updating component failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change component")
+		return
+	}
+	err = recordBugChanges(r.Context(), qtx, bug.ID, userID, []bugChange{
+		{"component_id", uuidValue(bug.ComponentID), uuidValue(componentID)},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change component")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change component")
+		return
+	}
+	bug.ComponentID = componentID
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponse(bug))
+}
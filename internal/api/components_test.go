@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var componentColumns = []string{"id", "project_id", "name", "description", "owner_id", "created_at", "updated_at"}
+
+func TestCreateBugHandlerAssignsComponentOwner(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	ownerID := uuid.New()
+	componentID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetProjectByKey :one`)).WithArgs("BUG").
+		WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(testProjectID, "BUG", "Default project", "", uuid.New(), time.Now(), time.Now(), 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetComponentByName :one`)).WithArgs(testProjectID, "auth").
+		WillReturnRows(sqlmock.NewRows(componentColumns).
+			AddRow(componentID, testProjectID, "auth", "", ownerID, time.Now(), time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: AllocateBugNumber :one`)).
+		WithArgs(testProjectID).WillReturnRows(sqlmock.NewRows([]string{"last_bug_number"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateBug :one`)).
+		WithArgs("login fails", "", userID, "major", "P2", testProjectID, 1, "BUG-1", ownerID, componentID, nil, false).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "login fails", "", userID, time.Now(), time.Now(), "open", "major", "P2", ownerID, testProjectID, 1, "BUG-1", nil, componentID, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddBugWatcher :exec`)).
+		WithArgs(bugID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddBugWatcher :exec`)).
+		WithArgs(bugID, ownerID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	body := bytes.NewBufferString(`{"title":"login fails","component":"auth"}`)
+	req := httptest.NewRequest("POST", "/api/bugs", body)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	cfg.CreateBugHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response CreateBugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.NotNil(t, response.AssigneeID) {
+		assert.Equal(t, ownerID, *response.AssigneeID)
+	}
+	if assert.NotNil(t, response.ComponentID) {
+		assert.Equal(t, componentID, *response.ComponentID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateComponentHandlerForbidsOtherUsers(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetProjectByKey :one`)).WithArgs("API").
+		WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(testProjectID, "API", "Public API", "", uuid.New(), time.Now(), time.Now(), 0))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/projects/{key}/components", cfg.CreateComponentHandler)
+	body := bytes.NewBufferString(`{"name":"auth","owner_id":"` + uuid.New().String() + `"}`)
+	req := httptest.NewRequest("POST", "/api/projects/API/components", body)
+	ctx := context.WithValue(req.Context(), "userID", uuid.New())
+	ctx = context.WithValue(ctx, "role", "user")
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanViewBug(t *testing.T) {
+	reporter := uuid.New()
+	assignee := uuid.New()
+	bug := database.Bug{PostedBy: reporter, AssigneeID: uuid.NullUUID{UUID: assignee, Valid: true}, Confidential: true}
+	stranger := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+
+	assert.True(t, canViewBug(bug, uuid.NullUUID{UUID: reporter, Valid: true}, "user"))
+	assert.True(t, canViewBug(bug, uuid.NullUUID{UUID: assignee, Valid: true}, "user"))
+	assert.True(t, canViewBug(bug, stranger, roleSecurity))
+	assert.True(t, canViewBug(bug, stranger, "admin"))
+	assert.False(t, canViewBug(bug, stranger, "user"))
+	assert.False(t, canViewBug(bug, uuid.NullUUID{}, ""))
+
+	bug.Confidential = false
+	assert.True(t, canViewBug(bug, uuid.NullUUID{}, ""))
+}
+
+func TestGetBugByIDHandlerHidesConfidentialBug(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}", cfg.GetBugByIDHandler)
+	for _, ctx := range []context.Context{
+		context.Background(),
+		context.WithValue(context.WithValue(context.Background(), "userID", uuid.New()), "role", "user"),
+	} {
+		mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+			WillReturnRows(sqlmock.NewRows(bugColumns).
+				AddRow(bugID, "sql injection in search", "", uuid.New(), time.Now(), time.Now(), "open", "critical", "P0", nil, testProjectID, 4, "BUG-4", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, true))
+		req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String(), nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugsHandlerShowsConfidentialBugsToSecurity(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: ListBugs :many`)).
+		WithArgs(nil, nil, nil, nil, nil, nil, nil, true, userID, "created").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "sql injection in search", "", uuid.New(), time.Now(), time.Now(), "open", "critical", "P0", nil, testProjectID, 4, "BUG-4", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, true))
+
+	req := httptest.NewRequest("GET", "/api/bugs", nil)
+	req = req.WithContext(context.WithValue(context.WithValue(req.Context(), "userID", userID), "role", roleSecurity))
+	w := httptest.NewRecorder()
+	cfg.GetBugsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.Len(t, response, 1) {
+		assert.True(t, response[0].Confidential)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetBugConfidentialHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "token leaks in logs", "", userID, time.Now(), time.Now(), "open", "critical", "P1", nil, testProjectID, 5, "BUG-5", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: SetBugConfidential :exec`)).WithArgs(bugID, true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "confidential", "false", "true").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/bugs/{bugid}/confidential", cfg.SetBugConfidentialHandler)
+	req := httptest.NewRequest("PUT", "/api/bugs/"+bugID.String()+"/confidential", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.True(t, response.Confidential)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetBugConfidentialHandlerForbidsOtherUsers(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "token leaks in logs", "", uuid.New(), time.Now(), time.Now(), "open", "critical", "P1", nil, testProjectID, 5, "BUG-5", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/bugs/{bugid}/confidential", cfg.SetBugConfidentialHandler)
+	req := httptest.NewRequest("PUT", "/api/bugs/"+bugID.String()+"/confidential", nil)
+	req = req.WithContext(context.WithValue(context.WithValue(req.Context(), "userID", uuid.New()), "role", "user"))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeBugHandlerKeepsConfidentialBugPrivate(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-6").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "xss in comments", "", uuid.New(), time.Now(), time.Now(), "open", "critical", "P1", nil, testProjectID, 6, "BUG-6", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-2").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "comments render html", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/merge", cfg.MergeBugHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/BUG-6/merge", bytes.NewBufferString(`{"into":"BUG-2"}`))
+	req = req.WithContext(context.WithValue(context.WithValue(req.Context(), "userID", uuid.New()), "role", "admin"))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugByIDHandlerHidesConfidentialMergeTarget(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	targetID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "crash", "", uuid.New(), time.Now(), time.Now(), "closed", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 0, nil, nil, "duplicate", 0, nil, targetID, false))
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(targetID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(targetID, "crash leaks session tokens", "", uuid.New(), time.Now(), time.Now(), "open", "critical", "P1", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetLabelsByBug :many`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(labelColumns))
+	mock.ExpectQuery(getCanonicalBugQuery).WithArgs(bugID).WillReturnRows(sqlmock.NewRows(bugColumns))
+	mock.ExpectQuery(getBugFieldValuesQuery).WithArgs(bugID).WillReturnRows(sqlmock.NewRows(fieldValueColumns))
+	mock.ExpectQuery(getSlaPolicyQuery).WithArgs("P2").WillReturnRows(sqlmock.NewRows(slaPolicyColumns))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}", cfg.GetBugByIDHandler)
+	req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String(), nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	assert.NotContains(t, w.Body.String(), targetID.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeBugHandlerRefusesConfidentialTarget(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-2").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "comments render html", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-6").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "xss in comments", "", uuid.New(), time.Now(), time.Now(), "open", "critical", "P1", nil, testProjectID, 6, "BUG-6", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, true))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/merge", cfg.MergeBugHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/BUG-2/merge", bytes.NewBufferString(`{"into":"BUG-6"}`))
+	req = req.WithContext(context.WithValue(context.WithValue(req.Context(), "userID", uuid.New()), "role", "admin"))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -6,12 +6,14 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/storage"
 )
 
 type APIConfig struct {
-	DB     *database.Queries
-	SECRET string
-	SQLDB  *sql.DB
+	DB      *database.Queries
+	SECRET  string
+	SQLDB   *sql.DB
+	Storage storage.Storage
 }
 
 func setupTest(t *testing.T) (*APIConfig, sqlmock.Sqlmock) {
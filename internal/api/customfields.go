@@ -0,0 +1,396 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// Custom field names show up in bug responses and as field.<name> filters, so they stay
+// short and safe to put in a query string.
+var customFieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+
+var validCustomFieldTypes = map[string]bool{
+	"text":   true,
+	"number": true,
+	"enum":   true,
+	"date":   true,
+	"user":   true,
+}
+
+// errInvalidFieldValue marks custom field input the client has to correct.
+var errInvalidFieldValue = errors.New("invalid custom field value")
+
+type CreateCustomFieldRequest struct {
+	Name    string   `json:"name" example:"environment"`
+	Type    string   `json:"type" example:"enum"`
+	Options []string `json:"options" example:"staging,production"`
+}
+
+type CustomFieldResponse struct {
+	ID        uuid.UUID `json:"id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Name      string    `json:"name" example:"environment"`
+	Type      string    `json:"type" example:"enum"`
+	Options   []string  `json:"options,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toCustomFieldResponse(field database.CustomField) CustomFieldResponse {
+	return CustomFieldResponse{
+		ID:        field.ID,
+		ProjectID: field.ProjectID,
+		Name:      field.Name,
+		Type:      field.FieldType,
+		Options:   field.Options,
+		CreatedAt: field.CreatedAt,
+	}
+}
+
+// fieldValueChange sets a custom field of a bug to Value, or clears it when Value is unset.
+type fieldValueChange struct {
+	Field database.CustomField
+	Value sql.NullString
+}
+
+// customFieldValue checks raw, as decoded from JSON, against the type of the field and
+// returns the canonical text form it is stored and filtered in.
+func (cfg *APIConfig) customFieldValue(ctx context.Context, field database.CustomField, raw any) (string, error) {
+	invalid := func(want string) error {
+		return fmt.Errorf("%w: %s must be %s", errInvalidFieldValue, field.Name, want)
+	}
+	if field.FieldType == "number" {
+		n, ok := raw.(float64)
+		if !ok {
+			return "", invalid("a number")
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return "", invalid("a string")
+	}
+	switch field.FieldType {
+	case "text":
+		return s, nil
+	case "enum":
+		if !slices.Contains(field.Options, s) {
+			return "", invalid("one of " + strings.Join(field.Options, ", "))
+		}
+		return s, nil
+	case "date":
+		if _, err := time.Parse(dateLayout, s); err != nil {
+			return "", invalid("a date formatted YYYY-MM-DD")
+		}
+		return s, nil
+	case "user":
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return "", invalid("a user id")
+		}
+		_, err = cfg.DB.GetUserByID(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%w: %s names a user that does not exist", errInvalidFieldValue, field.Name)
+		}
+		if err != nil {
+			return "", err
+		}
+		return id.String(), nil
+	}
+	return "", fmt.Errorf("custom field %s has unknown type %q", field.Name, field.FieldType)
+}
+
+// resolveFieldValues validates custom field input for a bug of the project, in name order.
+// A null value clears the field. Errors wrapping errInvalidFieldValue are the client's.
+func (cfg *APIConfig) resolveFieldValues(ctx context.Context, projectID uuid.UUID, values map[string]any) ([]fieldValueChange, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	fields, err := cfg.DB.GetCustomFieldsByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]database.CustomField, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	changes := make([]fieldValueChange, 0, len(names))
+	for _, name := range names {
+		field, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: the project has no field %s", errInvalidFieldValue, name)
+		}
+		if values[name] == nil {
+			changes = append(changes, fieldValueChange{Field: field})
+			continue
+		}
+		value, err := cfg.customFieldValue(ctx, field, values[name])
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, fieldValueChange{Field: field, Value: textValue(value)})
+	}
+	return changes, nil
+}
+
+// normalizeFieldFilters rewrites custom field filter values of a bug listing into the
+// form customFieldValue stores, so field.points=3.0 finds bugs saved with 3 and user
+// ids match whatever their case. Names whose fields differ in type between projects
+// are compared as given. Errors wrapping errInvalidFieldValue are the client's.
+func (cfg *APIConfig) normalizeFieldFilters(ctx context.Context, params *database.ListBugsParams) error {
+	if len(params.FieldNames) == 0 {
+		return nil
+	}
+	fields, err := cfg.DB.GetCustomFieldsByName(ctx, database.GetCustomFieldsByNameParams{
+		Names:     params.FieldNames,
+		ProjectID: params.ProjectID,
+	})
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]database.CustomField, len(fields))
+	mixed := make(map[string]bool)
+	for _, field := range fields {
+		if seen, ok := byName[field.Name]; ok && seen.FieldType != field.FieldType {
+			mixed[field.Name] = true
+		}
+		byName[field.Name] = field
+	}
+	for i, name := range params.FieldNames {
+		field, ok := byName[name]
+		if !ok || mixed[name] {
+			continue
+		}
+		var raw any = params.FieldValues[i]
+		switch field.FieldType {
+		case "text", "enum":
+			// stored as given; enum options may differ between projects
+			continue
+		case "number":
+			n, err := strconv.ParseFloat(params.FieldValues[i], 64)
+			if err != nil {
+				return fmt.Errorf("%w: %s must be a number", errInvalidFieldValue, name)
+			}
+			raw = n
+		}
+		value, err := cfg.customFieldValue(ctx, field, raw)
+		if err != nil {
+			return err
+		}
+		params.FieldValues[i] = value
+	}
+	return nil
+}
+
+// writeFieldValues stores the custom field changes of a bug.
+func writeFieldValues(ctx context.Context, q *database.Queries, bugID uuid.UUID, changes []fieldValueChange) error {
+	for _, change := range changes {
+		var err error
+		if change.Value.Valid {
+			err = q.SetBugFieldValue(ctx, database.SetBugFieldValueParams{
+				BugID:   bugID,
+				FieldID: change.Field.ID,
+				Value:   change.Value.String,
+			})
+		} else {
+			err = q.DeleteBugFieldValue(ctx, database.DeleteBugFieldValueParams{
+				BugID:   bugID,
+				FieldID: change.Field.ID,
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldHistory turns custom field changes into history entries named fields.<name>.
+func fieldHistory(current []database.GetBugFieldValuesRow, changes []fieldValueChange) []bugChange {
+	old := make(map[uuid.UUID]string, len(current))
+	for _, value := range current {
+		old[value.ID] = value.Value
+	}
+	history := make([]bugChange, 0, len(changes))
+	for _, change := range changes {
+		before := sql.NullString{}
+		if value, ok := old[change.Field.ID]; ok {
+			before = textValue(value)
+		}
+		history = append(history, bugChange{"fields." + change.Field.Name, before, change.Value})
+	}
+	return history
+}
+
+// fieldValueMap returns the custom field values of a bug as they appear in responses:
+// numbers as JSON numbers, everything else as strings.
+func fieldValueMap(values []database.GetBugFieldValuesRow) map[string]any {
+	if len(values) == 0 {
+		return nil
+	}
+	res := make(map[string]any, len(values))
+	for _, value := range values {
+		if value.FieldType == "number" {
+			if n, err := strconv.ParseFloat(value.Value, 64); err == nil {
+				res[value.Name] = n
+				continue
+			}
+		}
+		res[value.Name] = value.Value
+	}
+	return res
+}
+
+// @Summary Define a custom field
+// @Description Admins can add fields of type text, number, enum, date or user to the bugs of a project. Enum fields list their options.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Param request body CreateCustomFieldRequest true "name is lower case letters, digits and underscores"
+// @Success 201 {object} CustomFieldResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Field name already taken"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/fields [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateCustomFieldHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateCustomFieldHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	var req CreateCustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if !customFieldNamePattern.MatchString(req.Name) {
+		utils.RespondWithError(w, http.StatusBadRequest, "name must be up to 40 lower case letters, digits or underscores, starting with a letter")
+		return
+	}
+	if !validCustomFieldTypes[req.Type] {
+		utils.RespondWithError(w, http.StatusBadRequest, "type must be one of text, number, enum, date, user")
+		return
+	}
+	if req.Type == "enum" {
+		if len(req.Options) == 0 {
+			utils.RespondWithError(w, http.StatusBadRequest, "enum fields need at least one option")
+			return
+		}
+		seen := make(map[string]bool, len(req.Options))
+		for _, option := range req.Options {
+			if option == "" || seen[option] {
+				utils.RespondWithError(w, http.StatusBadRequest, "enum options must be unique and not empty")
+				return
+			}
+			seen[option] = true
+		}
+	} else if len(req.Options) > 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "only enum fields have options")
+		return
+	}
+
+	field, err := cfg.DB.CreateCustomField(r.Context(), database.CreateCustomFieldParams{
+		ProjectID: project.ID,
+		Name:      req.Name,
+		FieldType: req.Type,
+		Options:   req.Options,
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "field name already taken in this project")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create field")
+		return
+	}
+	logger.Info("custom field created", "fieldID", field.ID, "project", project.Key)
+	utils.RespondWithJSON(w, http.StatusCreated, toCustomFieldResponse(field))
+}
+
+// @Summary List custom fields of a project
+// @Tags projects
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Success 200 {array} CustomFieldResponse
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/fields [get]
+func (cfg *APIConfig) GetProjectCustomFieldsHandler(w http.ResponseWriter, r *http.Request) {
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	fields, err := cfg.DB.GetCustomFieldsByProject(r.Context(), project.ID)
+	if err != nil {
+		slog.Error("fetching custom fields failed", "project", project.Key, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch fields")
+		return
+	}
+	res := make([]CustomFieldResponse, 0, len(fields))
+	for _, field := range fields {
+		res = append(res, toCustomFieldResponse(field))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Delete a custom field
+// @Description Admins can remove a custom field, together with its values on every bug
+// @Tags projects
+// @Param id path string true "Field ID" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /fields/{id} [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) DeleteCustomFieldHandler(w http.ResponseWriter, r *http.Request) {
+	fieldID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format field Id")
+		return
+	}
+	deleted, err := cfg.DB.DeleteCustomField(r.Context(), fieldID)
+	if err != nil {
+		slog.Error("deleting custom field failed", "fieldID", fieldID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete field")
+		return
+	}
+	if deleted == 0 {
+		utils.RespondWithError(w, http.StatusNotFound, "no field found with the id")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
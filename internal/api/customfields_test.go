@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var customFieldColumns = []string{"id", "project_id", "name", "field_type", "options", "created_at"}
+
+var fieldValueColumns = []string{"id", "name", "field_type", "value"}
+
+var getBugFieldValuesQuery = regexp.QuoteMeta(`-- name: GetBugFieldValues :many`)
+
+func TestUpdateBugHandlerSetsCustomField(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	fieldID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCustomFieldsByProject :many`)).WithArgs(testProjectID).
+		WillReturnRows(sqlmock.NewRows(customFieldColumns).
+			AddRow(fieldID, testProjectID, "environment", "enum", `{staging,production}`, time.Now()))
+	mock.ExpectQuery(getBugFieldValuesQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(fieldValueColumns).AddRow(fieldID, "environment", "enum", "staging"))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: UpdateBugByID :exec`)).
+		WithArgs(bugID, nil, nil, nil, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: SetBugFieldValue :exec`)).
+		WithArgs(bugID, fieldID, "production").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "fields.environment", "staging", "production").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}", cfg.UpdateBugHandler)
+	body := bytes.NewBufferString(`{"fields":{"environment":"production"}}`)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String(), body)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateBugHandlerRejectsInvalidCustomField(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetProjectByKey :one`)).WithArgs("BUG").
+		WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(testProjectID, "BUG", "Default project", "", uuid.New(), time.Now(), time.Now(), 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCustomFieldsByProject :many`)).WithArgs(testProjectID).
+		WillReturnRows(sqlmock.NewRows(customFieldColumns).
+			AddRow(uuid.New(), testProjectID, "affected_users", "number", `{}`, time.Now()))
+
+	body := bytes.NewBufferString(`{"title":"checkout broken","fields":{"affected_users":"many"}}`)
+	req := httptest.NewRequest("POST", "/api/bugs", body)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", uuid.New()))
+	w := httptest.NewRecorder()
+	cfg.CreateBugHandler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "affected_users must be a number")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugsHandlerFiltersByCustomField(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCustomFieldsByName :many`)).
+		WithArgs("{\"environment\"}", nil).
+		WillReturnRows(sqlmock.NewRows(customFieldColumns).
+			AddRow(uuid.New(), testProjectID, "environment", "enum", "{production,staging}", time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: ListBugs :many`)).
+		WithArgs(nil, nil, nil, nil, "{\"environment\"}", "{\"production\"}", nil, false, nil, "created").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "outage", "", uuid.New(), time.Now(), time.Now(), "open", "blocker", "P0", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	req := httptest.NewRequest("GET", "/api/bugs?field.environment=production", nil)
+	w := httptest.NewRecorder()
+	cfg.GetBugsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []BugResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Len(t, response, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugsHandlerNormalizesCustomFieldFilters(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	ownerID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCustomFieldsByName :many`)).
+		WithArgs(sqlmock.AnyArg(), nil).
+		WillReturnRows(sqlmock.NewRows(customFieldColumns).
+			AddRow(uuid.New(), testProjectID, "affected_users", "number", "{}", time.Now()).
+			AddRow(uuid.New(), testProjectID, "owner", "user", "{}", time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetUserByID :one`)).WithArgs(ownerID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "email", "hashed_password", "role"}).
+			AddRow(ownerID, time.Now(), time.Now(), "dev@example.com", "unset", "user"))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: ListBugs :many`)).
+		WithArgs(nil, nil, nil, nil, "{\"affected_users\",\"owner\"}", "{\"3\",\""+ownerID.String()+"\"}", nil, false, nil, "created").
+		WillReturnRows(sqlmock.NewRows(bugColumns))
+
+	req := httptest.NewRequest("GET", "/api/bugs?field.affected_users=3.0&field.owner="+strings.ToUpper(ownerID.String()), nil)
+	w := httptest.NewRecorder()
+	cfg.GetBugsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type BugEventResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Field     string    `json:"field" example:"title"`
+	OldValue  *string   `json:"old_value"`
+	NewValue  *string   `json:"new_value"`
+	ActorID   uuid.UUID `json:"actor_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toBugEventResponse(event database.BugEvent) BugEventResponse {
+	res := BugEventResponse{
+		ID:        event.ID,
+		Field:     event.Field,
+		ActorID:   event.ActorID,
+		CreatedAt: event.CreatedAt,
+	}
+	if event.OldValue.Valid {
+		res.OldValue = &event.OldValue.String
+	}
+	if event.NewValue.Valid {
+		res.NewValue = &event.NewValue.String
+	}
+	return res
+}
+
+// bugChange is one field of a bug going from Old to New. An invalid value means the
+// field was unset, like a bug without assignee.
+type bugChange struct {
+	Field string
+	Old   sql.NullString
+	New   sql.NullString
+}
+
+func textValue(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: true}
+}
+
+func uuidValue(id uuid.NullUUID) sql.NullString {
+	if !id.Valid {
+		return sql.NullString{}
+	}
+	return textValue(id.UUID.String())
+}
+
+func timeValue(t sql.NullTime) sql.NullString {
+	if !t.Valid {
+		return sql.NullString{}
+	}
+	return textValue(t.Time.UTC().Format(time.RFC3339))
+}
+
+// recordBugChanges writes a history event for every change that alters its field. Pass
+// the transaction's queries so the history commits or rolls back with the change itself.
+func recordBugChanges(ctx context.Context, q *database.Queries, bugID, actorID uuid.UUID, changes []bugChange) error {
+	for _, change := range changes {
+		if change.Old == change.New {
+			continue
+		}
+		err := q.CreateBugEvent(ctx, database.CreateBugEventParams{
+			BugID:    bugID,
+			ActorID:  actorID,
+			Field:    change.Field,
+			OldValue: change.Old,
+			NewValue: change.New,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// @Summary Get the change history of a bug
+// @Description Returns every field change of the bug with old and new value, who made it and when, oldest first. Labels are recorded as added (new_value) or removed (old_value), and moving the bug to or out of the trash as a change of deleted_at
+// @Tags bugs
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {array} BugEventResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/history [get]
+func (cfg *APIConfig) GetBugHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "GetBugHistoryHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	events, err := cfg.DB.GetBugEvents(r.Context(), bug.ID)
+	if err != nil {
+		logger.Error("fetching bug history failed", "bugID", bug.ID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch history")
+		return
+	}
+	res := make([]BugEventResponse, 0, len(events))
+	for _, event := range events {
+		res = append(res, toBugEventResponse(event))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
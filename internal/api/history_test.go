@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var bugEventColumns = []string{"id", "bug_id", "actor_id", "field", "old_value", "new_value", "created_at"}
+
+func TestGetBugHistoryHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	actorID := uuid.New()
+	assigneeID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "new title", "test description", actorID, time.Now(), time.Now(), "open", "major", "P2", assigneeID, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugEvents :many SELECT id, bug_id, actor_id, field, old_value, new_value, created_at FROM bug_events WHERE bug_id = $1 ORDER BY created_at ASC`)).
+		WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugEventColumns).
+			AddRow(uuid.New(), bugID, actorID, "title", "old title", "new title", time.Now()).
+			AddRow(uuid.New(), bugID, actorID, "assignee_id", nil, assigneeID.String(), time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/history", cfg.GetBugHistoryHandler)
+	req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String()+"/history", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []BugEventResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.Len(t, response, 2) {
+		assert.Equal(t, "title", response[0].Field)
+		assert.Equal(t, "old title", *response[0].OldValue)
+		assert.Equal(t, actorID, response[0].ActorID)
+		assert.Nil(t, response[1].OldValue)
+		assert.Equal(t, assigneeID.String(), *response[1].NewValue)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
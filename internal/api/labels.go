@@ -0,0 +1,408 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const defaultLabelColor = "#cccccc"
+
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type CreateLabelRequest struct {
+	Name        string `json:"name" example:"regression"`
+	Color       string `json:"color" example:"#d73a4a"`
+	Description string `json:"description" example:"Worked in a previous release"`
+}
+
+type UpdateLabelRequest struct {
+	Name        *string `json:"name" example:"regression"`
+	Color       *string `json:"color" example:"#d73a4a"`
+	Description *string `json:"description" example:"Worked in a previous release"`
+}
+
+type LabelResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func toLabelResponse(label database.Label) LabelResponse {
+	return LabelResponse{
+		ID:          label.ID,
+		Name:        label.Name,
+		Color:       label.Color,
+		Description: label.Description,
+		CreatedAt:   label.CreatedAt,
+		UpdatedAt:   label.UpdatedAt,
+	}
+}
+
+func toLabelResponses(labels []database.Label) []LabelResponse {
+	res := make([]LabelResponse, 0, len(labels))
+	for _, label := range labels {
+		res = append(res, toLabelResponse(label))
+	}
+	return res
+}
+
+func labelNames(labels []database.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// @Summary List labels
+// @Description Returns every label that can be put on a bug
+// @Tags labels
+// @Produce json
+// @Success 200 {array} LabelResponse
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /labels [get]
+func (cfg *APIConfig) GetLabelsHandler(w http.ResponseWriter, r *http.Request) {
+	labels, err := cfg.DB.GetAllLabels(r.Context())
+	if err != nil {
+		slog.Error("fetching labels failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch labels")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toLabelResponses(labels))
+}
+
+// @Summary Create a label
+// @Description admin can create labels to slice the backlog with
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Param request body CreateLabelRequest true "label creation data"
+// @Success 201 {object} LabelResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Label already exists"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /labels [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateLabelHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateLabelHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	var req CreateLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "name field required")
+		return
+	}
+	if req.Color == "" {
+		req.Color = defaultLabelColor
+	}
+	if !labelColorPattern.MatchString(req.Color) {
+		utils.RespondWithError(w, http.StatusBadRequest, "color must look like #rrggbb")
+		return
+	}
+
+	label, err := cfg.DB.CreateLabel(r.Context(), database.CreateLabelParams{
+		Name:        req.Name,
+		Color:       req.Color,
+		Description: req.Description,
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "label already exists")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create label")
+		return
+	}
+	logger.Info("label created", "labelID", label.ID, "name", label.Name)
+	utils.RespondWithJSON(w, http.StatusCreated, toLabelResponse(label))
+}
+
+// @Summary Update a label
+// @Description admin can rename or recolor a label
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Param labelid path string true "Label ID" example:"5b0c6b8e-8f0e-4d1a-9a3c-2f6d7e8f9a0b"
+// @Param request body UpdateLabelRequest true "label updation data"
+// @Success 200 {object} LabelResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Label already exists"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /labels/{labelid} [put]
+// @Security BearerAuth
+func (cfg *APIConfig) UpdateLabelHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UpdateLabelHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	labelID, err := uuid.Parse(r.PathValue("labelid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return
+	}
+	var req UpdateLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "name cannot be empty")
+		return
+	}
+	if req.Color != nil && !labelColorPattern.MatchString(*req.Color) {
+		utils.RespondWithError(w, http.StatusBadRequest, "color must look like #rrggbb")
+		return
+	}
+
+	label, err := cfg.DB.UpdateLabel(r.Context(), database.UpdateLabelParams{
+		ID:          labelID,
+		Name:        toNullString(req.Name),
+		Color:       toNullString(req.Color),
+		Description: toNullString(req.Description),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "no label found with the id")
+		return
+	}
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "label already exists")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update label")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toLabelResponse(label))
+}
+
+// @Summary Delete a label
+// @Description admin can delete a label, which also removes it from every bug
+// @Tags labels
+// @Param labelid path string true "Label ID" example:"5b0c6b8e-8f0e-4d1a-9a3c-2f6d7e8f9a0b"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /labels/{labelid} [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) DeleteLabelHandler(w http.ResponseWriter, r *http.Request) {
+	labelID, err := uuid.Parse(r.PathValue("labelid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return
+	}
+	deleted, err := cfg.DB.DeleteLabel(r.Context(), labelID)
+	if err != nil {
+		slog.Error("deleting label failed", "labelID", labelID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete label")
+		return
+	}
+	if deleted == 0 {
+		utils.RespondWithError(w, http.StatusNotFound, "no label found with the id")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Add a label to a bug
+// @Description The author, the assignee or an admin can label a bug
+// @Tags labels
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param label path string true "Label name" example:"regression"
+// @Success 200 {array} LabelResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/labels/{label} [put]
+// @Security BearerAuth
+func (cfg *APIConfig) AddBugLabelHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "AddBugLabelHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	bug, label, ok := cfg.bugAndLabelFromPath(w, r, logger)
+	if !ok {
+		return
+	}
+	userID, _ := r.Context().Value("userID").(uuid.UUID)
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot add label")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	added, err := qtx.AddLabelToBug(r.Context(), database.AddLabelToBugParams{
+		BugID:   bug.ID,
+		LabelID: label.ID,
+	})
+	if err != nil {
+		logger.Error("adding label failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot add label")
+		return
+	}
+	if added > 0 {
+		err = recordBugChanges(r.Context(), qtx, bug.ID, userID, []bugChange{
+			{"labels", sql.NullString{}, textValue(label.Name)},
+		})
+		if err != nil {
+			logger.Error("recording bug history failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot add label")
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot add label")
+		return
+	}
+	cfg.respondWithBugLabels(w, r, logger, bug.ID)
+}
+
+// @Summary Remove a label from a bug
+// @Description The author, the assignee or an admin can remove a label from a bug
+// @Tags labels
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param label path string true "Label name" example:"regression"
+// @Success 200 {array} LabelResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/labels/{label} [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) RemoveBugLabelHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "RemoveBugLabelHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	bug, label, ok := cfg.bugAndLabelFromPath(w, r, logger)
+	if !ok {
+		return
+	}
+	userID, _ := r.Context().Value("userID").(uuid.UUID)
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot remove label")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	removed, err := qtx.RemoveLabelFromBug(r.Context(), database.RemoveLabelFromBugParams{
+		BugID:   bug.ID,
+		LabelID: label.ID,
+	})
+	if err != nil {
+		logger.Error("removing label failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot remove label")
+		return
+	}
+	if removed == 0 {
+		utils.RespondWithError(w, http.StatusNotFound, "bug does not have this label")
+		return
+	}
+	err = recordBugChanges(r.Context(), qtx, bug.ID, userID, []bugChange{
+		{"labels", textValue(label.Name), sql.NullString{}},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot remove label")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot remove label")
+		return
+	}
+	cfg.respondWithBugLabels(w, r, logger, bug.ID)
+}
+
+// bugAndLabelFromPath resolves the bug and label named in the request path and checks
+// that the user may relabel the bug. It writes the error response itself when it returns false.
+func (cfg *APIConfig) bugAndLabelFromPath(w http.ResponseWriter, r *http.Request, logger *slog.Logger) (database.Bug, database.Label, bool) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return database.Bug{}, database.Label{}, false
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	bugID, err := uuid.Parse(r.PathValue("bugid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format Id")
+		return database.Bug{}, database.Label{}, false
+	}
+	bug, err := cfg.getBugByRef(r.Context(), bugID.String())
+	if err != nil {
+		logger.Error("bug not found in database", "bugID", bugID, "error", err)
+		utils.RespondWithError(w, http.StatusNotFound, "no bug found with the id")
+		return database.Bug{}, database.Label{}, false
+	}
+	if !canManageBug(bug, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only author, assignee or admin can change labels")
+		return database.Bug{}, database.Label{}, false
+	}
+	label, err := cfg.DB.GetLabelByName(r.Context(), r.PathValue("label"))
+	if err != nil {
+		logger.Error("label not found in database", "label", r.PathValue("label"), "error", err)
+		utils.RespondWithError(w, http.StatusNotFound, "no label found with the name")
+		return database.Bug{}, database.Label{}, false
+	}
+	return bug, label, true
+}
+
+func (cfg *APIConfig) respondWithBugLabels(w http.ResponseWriter, r *http.Request, logger *slog.Logger, bugID uuid.UUID) {
+	labels, err := cfg.DB.GetLabelsByBug(r.Context(), bugID)
+	if err != nil {
+		logger.Error("fetching bug labels failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch labels")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toLabelResponses(labels))
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var labelColumns = []string{"id", "name", "color", "description", "created_at", "updated_at"}
+
+func TestCreateLabelHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	labelID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateLabel :one`)).
+		WithArgs("regression", "#cccccc", "worked before").
+		WillReturnRows(sqlmock.NewRows(labelColumns).
+			AddRow(labelID, "regression", "#cccccc", "worked before", time.Now(), time.Now()))
+
+	req := httptest.NewRequest("POST", "/api/labels", bytes.NewBufferString(`{"name":" regression ","description":"worked before"}`))
+	w := httptest.NewRecorder()
+	cfg.CreateLabelHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response LabelResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, labelID, response.ID)
+	assert.Equal(t, "#cccccc", response.Color)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateLabelHandlerDuplicate(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateLabel :one`)).
+		WithArgs("ui", "#0075ca", "").
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	req := httptest.NewRequest("POST", "/api/labels", bytes.NewBufferString(`{"name":"ui","color":"#0075ca"}`))
+	w := httptest.NewRecorder()
+	cfg.CreateLabelHandler(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteLabelHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	labelID := uuid.New()
+	missingID := uuid.New()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: DeleteLabel :execrows`)).WithArgs(labelID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: DeleteLabel :execrows`)).WithArgs(missingID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /api/labels/{labelid}", cfg.DeleteLabelHandler)
+	req := httptest.NewRequest("DELETE", "/api/labels/"+labelID.String(), nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	req = httptest.NewRequest("DELETE", "/api/labels/"+missingID.String(), nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddBugLabelHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	labelID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetLabelByName :one`)).WithArgs("ui").
+		WillReturnRows(sqlmock.NewRows(labelColumns).AddRow(labelID, "ui", "#0075ca", "", time.Now(), time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO bug_labels (bug_id, label_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`)).
+		WithArgs(bugID, labelID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "labels", nil, "ui").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetLabelsByBug :many`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(labelColumns).AddRow(labelID, "ui", "#0075ca", "", time.Now(), time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/labels/{label}", cfg.AddBugLabelHandler)
+	req := httptest.NewRequest("PUT", "/api/bugs/"+bugID.String()+"/labels/ui", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []LabelResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.Len(t, response, 1) {
+		assert.Equal(t, "ui", response[0].Name)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveBugLabelHandlerRecordsHistory(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	labelID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetLabelByName :one`)).WithArgs("ui").
+		WillReturnRows(sqlmock.NewRows(labelColumns).AddRow(labelID, "ui", "#0075ca", "", time.Now(), time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: RemoveLabelFromBug :execrows`)).
+		WithArgs(bugID, labelID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "labels", "ui", nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetLabelsByBug :many`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(labelColumns))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/labels/{label}", cfg.RemoveBugLabelHandler)
+	req := httptest.NewRequest("DELETE", "/api/bugs/"+bugID.String()+"/labels/ui", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugsHandlerFiltersByLabel(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: ListBugs :many`)).
+		WithArgs(nil, nil, "{\"regression\",\"ui\"}", nil, nil, nil, nil, false, nil, "created").
+		WillReturnRows(sqlmock.NewRows(bugColumns))
+
+	req := httptest.NewRequest("GET", "/api/bugs?label=regression&label=ui", nil)
+	w := httptest.NewRecorder()
+	cfg.GetBugsHandler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,307 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type CreateBugLinkRequest struct {
+	Type   string `json:"type" example:"blocked-by"`
+	Target string `json:"target" example:"API-42"`
+}
+
+type LinkedBug struct {
+	ID     uuid.UUID `json:"id"`
+	Key    string    `json:"key"`
+	Title  string    `json:"title,omitempty"`
+	Status string    `json:"status,omitempty"`
+}
+
+type BugLinkResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type" example:"blocked-by"`
+	Bug       LinkedBug `json:"bug"`
+	CreatedBy uuid.UUID `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// bugLinkTypes maps every link type a client can ask for to the type that is stored, and
+// whether the bug in the path ends up as the target of the stored link instead of its source.
+var bugLinkTypes = map[string]struct {
+	stored   string
+	reversed bool
+}{
+	"duplicate-of": {"duplicate-of", false},
+	"blocks":       {"blocks", false},
+	"blocked-by":   {"blocks", true},
+	"relates-to":   {"relates-to", false},
+	"parent-of":    {"parent-of", false},
+	"child-of":     {"parent-of", true},
+}
+
+// inverseBugLinkTypes names a stored link as seen from its target.
+var inverseBugLinkTypes = map[string]string{
+	"duplicate-of": "duplicated-by",
+	"blocks":       "blocked-by",
+	"relates-to":   "relates-to",
+	"parent-of":    "child-of",
+}
+
+func toBugLinkResponse(link database.GetBugLinksRow, bugID uuid.UUID) BugLinkResponse {
+	res := BugLinkResponse{
+		ID:   link.ID,
+		Type: link.LinkType,
+		Bug: LinkedBug{
+			ID:     link.TargetID,
+			Key:    link.LinkedKey,
+			Title:  link.LinkedTitle,
+			Status: link.LinkedStatus,
+		},
+		CreatedBy: link.CreatedBy,
+		CreatedAt: link.CreatedAt,
+	}
+	if link.TargetID == bugID {
+		res.Type = inverseBugLinkTypes[link.LinkType]
+		res.Bug.ID = link.SourceID
+	}
+	return res
+}
+
+// @Summary Link two bugs
+// @Description The author, the assignee or an admin can link a bug to another one. Marking a bug as duplicate-of resolves it as a duplicate.
+// @Description Links of type blocks, parent-of and duplicate-of may not form a cycle.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param request body CreateBugLinkRequest true "type is one of duplicate-of, blocks, blocked-by, relates-to, parent-of, child-of"
+// @Success 201 {object} BugLinkResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Link exists or would create a cycle"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/links [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateBugLinkHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateBugLinkHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	var req CreateBugLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	linkType, known := bugLinkTypes[req.Type]
+	if !known {
+		utils.RespondWithError(w, http.StatusBadRequest, "type must be one of duplicate-of, blocks, blocked-by, relates-to, parent-of, child-of")
+		return
+	}
+
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	if !canManageBug(bug, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only author, assignee or admin can link the bug")
+		return
+	}
+	other, ok := cfg.bugFromRef(w, r, logger, req.Target)
+	if !ok {
+		return
+	}
+	if other.ID == bug.ID {
+		utils.RespondWithError(w, http.StatusBadRequest, "a bug cannot be linked to itself")
+		return
+	}
+
+	source, target := bug.ID, other.ID
+	if linkType.reversed {
+		source, target = target, source
+	}
+	// relates-to reads the same both ways, so store it one way only.
+	if linkType.stored == "relates-to" && bytes.Compare(source[:], target[:]) > 0 {
+		source, target = target, source
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot link bugs")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	if linkType.stored != "relates-to" {
+		cycle, err := qtx.LinkPathExists(r.Context(), database.LinkPathExistsParams{
+			FromID:   target,
+			LinkType: linkType.stored,
+			ToID:     source,
+		})
+		if err != nil {
+			logger.Error("checking for link cycle failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot link bugs")
+			return
+		}
+		if cycle {
+			utils.RespondWithError(w, http.StatusConflict, "link would create a "+linkType.stored+" cycle")
+			return
+		}
+	}
+
+	link, err := qtx.CreateBugLink(r.Context(), database.CreateBugLinkParams{
+		SourceID:  source,
+		TargetID:  target,
+		LinkType:  linkType.stored,
+		CreatedBy: userID,
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "link already exists or bug is already a duplicate")
+		return
+	}
+	if err != nil {
+		logger.Error("creating link failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot link bugs")
+		return
+	}
+
+	if linkType.stored == "duplicate-of" && isValidTransition(bug.Status, "resolved") {
+		changed, err := changeBugStatus(r.Context(), qtx, bug, "resolved", resolutionDuplicate, userID)
+		if err != nil {
+			logger.Error("resolving duplicate failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot link bugs")
+			return
+		}
+		if !changed {
+			utils.RespondWithError(w, http.StatusConflict, "bug status was changed by someone else, retry")
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot link bugs")
+		return
+	}
+
+	logger.Info("bugs linked", "source", source, "target", target, "type", linkType.stored)
+	utils.RespondWithJSON(w, http.StatusCreated, toBugLinkResponse(database.GetBugLinksRow{
+		ID:           link.ID,
+		SourceID:     link.SourceID,
+		TargetID:     link.TargetID,
+		LinkType:     link.LinkType,
+		CreatedBy:    link.CreatedBy,
+		CreatedAt:    link.CreatedAt,
+		LinkedKey:    other.Key,
+		LinkedTitle:  other.Title,
+		LinkedStatus: other.Status,
+	}, bug.ID))
+}
+
+// @Summary List links of a bug
+// @Description Returns every link of the bug, typed as seen from this bug (e.g. blocked-by, child-of, duplicated-by)
+// @Tags bugs
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {array} BugLinkResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/links [get]
+func (cfg *APIConfig) GetBugLinksHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "GetBugLinksHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	viewer, role := viewerFromContext(r.Context())
+	links, err := cfg.DB.GetBugLinks(r.Context(), database.GetBugLinksParams{
+		BugID:           bug.ID,
+		SeeConfidential: seesConfidentialBugs(role),
+		ViewerID:        viewer,
+	})
+	if err != nil {
+		logger.Error("fetching links failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch links")
+		return
+	}
+	res := make([]BugLinkResponse, 0, len(links))
+	for _, link := range links {
+		res = append(res, toBugLinkResponse(link, bug.ID))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Remove a link between bugs
+// @Description The author, the assignee or an admin can remove a link of the bug. Removing duplicate-of does not reopen the bug.
+// @Tags bugs
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param linkid path string true "Link ID" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/links/{linkid} [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) DeleteBugLinkHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "DeleteBugLinkHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	linkID, err := uuid.Parse(r.PathValue("linkid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format link Id")
+		return
+	}
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	link, err := cfg.DB.GetBugLinkByID(r.Context(), linkID)
+	if err != nil || (link.SourceID != bug.ID && link.TargetID != bug.ID) {
+		utils.RespondWithError(w, http.StatusNotFound, "no link found with the id on this bug")
+		return
+	}
+	if !canManageBug(bug, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only author, assignee or admin can unlink the bug")
+		return
+	}
+	if err := cfg.DB.DeleteBugLink(r.Context(), linkID); err != nil {
+		logger.Error("deleting link failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot remove link")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var linkPathExistsQuery = regexp.QuoteMeta(`-- name: LinkPathExists :one`)
+
+func TestCreateBugLinkHandlerResolvesDuplicate(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	canonicalID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "login broken", "again", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 3, "BUG-3", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-2").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(canonicalID, "login broken", "first report", uuid.New(), time.Now(), time.Now(), "triaged", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectQuery(linkPathExistsQuery).WithArgs(canonicalID, "duplicate-of", bugID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateBugLink :one`)).WithArgs(bugID, canonicalID, "duplicate-of", userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "source_id", "target_id", "link_type", "created_by", "created_at"}).
+			AddRow(uuid.New(), bugID, canonicalID, "duplicate-of", userID, time.Now()))
+	mock.ExpectExec(updateBugStatusQuery).
+		WithArgs(bugID, "resolved", "duplicate", "open").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bug_status_transitions`)).
+		WithArgs(bugID, "open", "resolved", userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bug_id", "from_status", "to_status", "changed_by", "changed_at"}).
+			AddRow(uuid.New(), bugID, "open", "resolved", userID, time.Now()))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "status", "open", "resolved").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "resolution", nil, "duplicate").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/links", cfg.CreateBugLinkHandler)
+	body := bytes.NewBufferString(`{"type":"duplicate-of","target":"BUG-2"}`)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/links", body)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BugLinkResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, "duplicate-of", response.Type)
+	assert.Equal(t, canonicalID, response.Bug.ID)
+	assert.Equal(t, "BUG-2", response.Bug.Key)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateBugLinkHandlerRejectsBlockingCycle(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	blockerID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "deploy fails", "", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(blockerID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(blockerID, "ci is red", "", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectBegin()
+	// bug is blocked-by blocker, stored as blocker blocks bug; blocker already waits on bug.
+	mock.ExpectQuery(linkPathExistsQuery).WithArgs(bugID, "blocks", blockerID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/links", cfg.CreateBugLinkHandler)
+	body := bytes.NewBufferString(`{"type":"blocked-by","target":"` + blockerID.String() + `"}`)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/links", body)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugLinksHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	blockerID := uuid.New()
+	childID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "deploy fails", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugLinks :many`)).WithArgs(bugID, false, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "source_id", "target_id", "link_type", "created_by", "created_at", "linked_key", "linked_title", "linked_status"}).
+			AddRow(uuid.New(), blockerID, bugID, "blocks", uuid.New(), time.Now(), "BUG-2", "ci is red", "open").
+			AddRow(uuid.New(), bugID, childID, "parent-of", uuid.New(), time.Now(), "BUG-5", "staging only", "open"))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/links", cfg.GetBugLinksHandler)
+	req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String()+"/links", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []BugLinkResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.Len(t, response, 2) {
+		assert.Equal(t, "blocked-by", response[0].Type)
+		assert.Equal(t, blockerID, response[0].Bug.ID)
+		assert.Equal(t, "parent-of", response[1].Type)
+		assert.Equal(t, childID, response[1].Bug.ID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
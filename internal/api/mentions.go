@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// mentionPattern matches @handle and @email tokens that start a word, so addresses
+// like dev@example.com in running text are not read as mentions of "example.com".
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([\w.+-]+(?:@[\w-]+(?:\.[\w-]+)+)?)`)
+
+type MentionResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	BugID       uuid.UUID  `json:"bug_id"`
+	BugKey      string     `json:"bug_key" example:"API-123"`
+	BugTitle    string     `json:"bug_title"`
+	CommentID   *uuid.UUID `json:"comment_id"`
+	MentionedBy uuid.UUID  `json:"mentioned_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// parseMentions returns the lowercased handles and email addresses mentioned in
+// text, in order of first appearance and without duplicates.
+func parseMentions(text string) []string {
+	var mentions []string
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		token := strings.ToLower(strings.TrimRight(m[1], ".-"))
+		if token == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		mentions = append(mentions, token)
+	}
+	return mentions
+}
+
+// resolveMentions looks up the users mentioned in text. A handle is the part of an
+// email address before the @ and only resolves when exactly one user has it;
+// anything that does not resolve stays plain text.
+func (cfg *APIConfig) resolveMentions(ctx context.Context, text string) ([]database.GetMentionCandidatesRow, error) {
+	tokens := parseMentions(text)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	var emails, handles []string
+	for _, token := range tokens {
+		if strings.Contains(token, "@") {
+			emails = append(emails, token)
+		} else {
+			handles = append(handles, token)
+		}
+	}
+	candidates, err := cfg.DB.GetMentionCandidates(ctx, database.GetMentionCandidatesParams{
+		Emails:  emails,
+		Handles: handles,
+	})
+	if err != nil {
+		return nil, err
+	}
+	byEmail := make(map[string]database.GetMentionCandidatesRow)
+	byHandle := make(map[string][]database.GetMentionCandidatesRow)
+	for _, c := range candidates {
+		email := strings.ToLower(c.Email)
+		byEmail[email] = c
+		handle, _, _ := strings.Cut(email, "@")
+		byHandle[handle] = append(byHandle[handle], c)
+	}
+
+	var users []database.GetMentionCandidatesRow
+	seen := make(map[uuid.UUID]bool)
+	for _, token := range tokens {
+		user, ok := byEmail[token]
+		if !strings.Contains(token, "@") {
+			ok = len(byHandle[token]) == 1
+			if ok {
+				user = byHandle[token][0]
+			}
+		}
+		if !ok || seen[user.ID] {
+			continue
+		}
+		seen[user.ID] = true
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// recordMentions stores the mentions made by author on a bug, or on one of its
+// comments when commentID is set, and makes every mentioned user watch the bug.
+// Authors mentioning themselves and users who cannot see a confidential bug are
+// ignored.
+func recordMentions(ctx context.Context, q *database.Queries, bugID uuid.UUID, commentID uuid.NullUUID, author uuid.UUID, users []database.GetMentionCandidatesRow) error {
+	var bug database.Bug
+	for _, user := range users {
+		if user.ID == author {
+			continue
+		}
+		if bug.ID == uuid.Nil {
+			var err error
+			if bug, err = q.GetBugsByID(ctx, bugID); err != nil {
+				return err
+			}
+		}
+		if !canViewBug(bug, uuid.NullUUID{UUID: user.ID, Valid: true}, user.Role) {
+			continue
+		}
+		err := q.CreateMention(ctx, database.CreateMentionParams{
+			BugID:       bugID,
+			CommentID:   commentID,
+			UserID:      user.ID,
+			MentionedBy: author,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.AddBugWatcher(ctx, database.AddBugWatcherParams{BugID: bugID, UserID: user.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// @Summary List my mentions
+// @Description Returns the places where the logged in user was mentioned in a bug description or comment, newest first
+// @Tags users
+// @Produce json
+// @Success 200 {array} MentionResponse
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /users/me/mentions [get]
+// @Security BearerAuth
+func (cfg *APIConfig) GetMyMentionsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	mentions, err := cfg.DB.GetMentionsByUser(r.Context(), database.GetMentionsByUserParams{
+		UserID:          userID,
+		SeeConfidential: seesConfidentialBugs(role),
+	})
+	if err != nil {
+		slog.Error("fetching mentions failed", "userID", userID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch mentions")
+		return
+	}
+	res := make([]MentionResponse, 0, len(mentions))
+	for _, m := range mentions {
+		mention := MentionResponse{
+			ID:          m.ID,
+			BugID:       m.BugID,
+			BugKey:      m.BugKey,
+			BugTitle:    m.BugTitle,
+			MentionedBy: m.MentionedBy,
+			CreatedAt:   m.CreatedAt,
+		}
+		if m.CommentID.Valid {
+			mention.CommentID = &m.CommentID.UUID
+		}
+		res = append(res, mention)
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
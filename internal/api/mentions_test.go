@@ -0,0 +1,160 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	mentions := parseMentions("@Alice can you look? cc @bob@example.com, @alice. Mail dev@example.com for logs")
+	assert.Equal(t, []string{"alice", "bob@example.com"}, mentions)
+}
+
+func TestCreateCommentHandlerRecordsMentions(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	commentID := uuid.New()
+	aliceID := uuid.New()
+	body := "@alice @ghost can you take a look?"
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetMentionCandidates :many`)).
+		WithArgs(nil, "{\"alice\",\"ghost\"}").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role"}).AddRow(aliceID, "Alice@example.com", "user"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateComment :one`)).
+		WithArgs(bugID, userID, nil, body).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(commentID, bugID, userID, nil, body, time.Now(), time.Now(), nil))
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: CreateMention :exec`)).
+		WithArgs(bugID, commentID, aliceID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddBugWatcher :exec`)).
+		WithArgs(bugID, aliceID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/comments", cfg.CreateCommentHandler)
+	requestBody, _ := json.Marshal(CreateCommentRequest{Body: body})
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/comments", bytes.NewBuffer(requestBody))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response CommentResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, body, response.Body)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateCommentHandlerSkipsMentionsOnHiddenBug(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	commentID := uuid.New()
+	aliceID := uuid.New()
+	securityID := uuid.New()
+	body := "@alice @sec can you take a look?"
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, true))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetMentionCandidates :many`)).
+		WithArgs(nil, "{\"alice\",\"sec\"}").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role"}).
+			AddRow(aliceID, "alice@example.com", "user").
+			AddRow(securityID, "sec@example.com", "security"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateComment :one`)).
+		WithArgs(bugID, userID, nil, body).
+		WillReturnRows(sqlmock.NewRows(commentColumns).
+			AddRow(commentID, bugID, userID, nil, body, time.Now(), time.Now(), nil))
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, true))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: CreateMention :exec`)).
+		WithArgs(bugID, commentID, securityID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddBugWatcher :exec`)).
+		WithArgs(bugID, securityID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/comments", cfg.CreateCommentHandler)
+	requestBody, _ := json.Marshal(CreateCommentRequest{Body: body})
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/comments", bytes.NewBuffer(requestBody))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveMentionsSkipsAmbiguousHandles(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetMentionCandidates :many`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role"}).
+			AddRow(uuid.New(), "sam@example.com", "user").
+			AddRow(uuid.New(), "sam@example.org", "user"))
+
+	users, err := cfg.resolveMentions(context.Background(), "thanks @sam")
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMyMentionsHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	commentID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetMentionsByUser :many`)).WithArgs(userID, false).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bug_id", "comment_id", "mentioned_by", "created_at", "bug_key", "bug_title"}).
+			AddRow(uuid.New(), bugID, commentID, uuid.New(), time.Now(), "API-7", "slow search").
+			AddRow(uuid.New(), bugID, nil, uuid.New(), time.Now(), "API-7", "slow search"))
+
+	req := httptest.NewRequest("GET", "/api/users/me/mentions", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	cfg.GetMyMentionsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []MentionResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	if assert.Len(t, response, 2) {
+		assert.Equal(t, "API-7", response[0].BugKey)
+		if assert.NotNil(t, response[0].CommentID) {
+			assert.Equal(t, commentID, *response[0].CommentID)
+		}
+		assert.Nil(t, response[1].CommentID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
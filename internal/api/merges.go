@@ -0,0 +1,255 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type MergeBugRequest struct {
+	// Into names the bug that survives the merge, by UUID or key.
+	Into string `json:"into" example:"API-12"`
+}
+
+// MergeBugResponse returns the surviving bug and how much was moved onto it. Watchers
+// and votes already on the surviving bug are not counted again.
+type MergeBugResponse struct {
+	Bug         BugResponse `json:"bug"`
+	MergedKey   string      `json:"merged_key" example:"API-57"`
+	Comments    int64       `json:"comments"`
+	Attachments int64       `json:"attachments"`
+	Watchers    int64       `json:"watchers"`
+	Votes       int64       `json:"votes"`
+}
+
+// @Summary Merge a bug into another one
+// @Description admin can merge a duplicate into the bug that survives. Comments, attachments, watchers and votes move to the surviving bug; attachments it already has stay behind.
+// @Description The duplicate is closed as a duplicate of the surviving bug and redirects to it from then on. Both bugs record the merge in their history.
+// @Description A confidential bug can only be merged into a confidential bug and a public bug only into a public one.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID or key of the duplicate" example:"API-57"
+// @Param request body MergeBugRequest true "bug to merge into"
+// @Success 200 {object} MergeBugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Bug already merged"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/merge [post]
+// @Security BearerAuth
+func (cfg *APIConfig) MergeBugHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "MergeBugHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		logger.Error("user id not given or invalid")
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	logger = logger.With("userID", userID)
+
+	var req MergeBugRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("given request body in wrong format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Into == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "into field required")
+		return
+	}
+	source, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	target, ok := cfg.bugFromRef(w, r, logger, req.Into)
+	if !ok {
+		return
+	}
+	logger = logger.With("source", source.ID, "target", target.ID)
+	if source.ID == target.ID {
+		utils.RespondWithError(w, http.StatusBadRequest, "a bug cannot be merged into itself")
+		return
+	}
+	if source.MergedIntoID.Valid {
+		utils.RespondWithError(w, http.StatusConflict, source.Key+" was already merged")
+		return
+	}
+	if target.MergedIntoID.Valid {
+		utils.RespondWithError(w, http.StatusConflict, target.Key+" was merged into another bug, merge into that one")
+		return
+	}
+	if source.Confidential && !target.Confidential {
+		utils.RespondWithError(w, http.StatusConflict, "merging a confidential bug into a public one would publish its comments and attachments")
+		return
+	}
+	if !source.Confidential && target.Confidential {
+		utils.RespondWithError(w, http.StatusConflict, "merging a public bug into a confidential one would point its readers at a bug they cannot see")
+		return
+	}
+	cycle, err := cfg.DB.LinkPathExists(r.Context(), database.LinkPathExistsParams{
+		FromID:   target.ID,
+		LinkType: "duplicate-of",
+		ToID:     source.ID,
+	})
+	if err != nil {
+		logger.Error("checking duplicate chain failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	if cycle {
+		utils.RespondWithError(w, http.StatusConflict, target.Key+" is itself a duplicate of "+source.Key)
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	res := MergeBugResponse{MergedKey: source.Key}
+	res.Comments, err = qtx.MoveComments(r.Context(), database.MoveCommentsParams{ToBugID: target.ID, FromBugID: source.ID})
+	if err != nil {
+		logger.Error("moving comments failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	err = qtx.MoveCommentMentions(r.Context(), database.MoveCommentMentionsParams{ToBugID: target.ID, FromBugID: source.ID})
+	if err != nil {
+		logger.Error("moving mentions failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	res.Attachments, err = qtx.MoveAttachments(r.Context(), database.MoveAttachmentsParams{ToBugID: target.ID, FromBugID: source.ID})
+	if err != nil {
+		logger.Error("moving attachments failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	res.Watchers, err = qtx.MoveBugWatchers(r.Context(), database.MoveBugWatchersParams{FromBugID: source.ID, ToBugID: target.ID})
+	if err != nil {
+		logger.Error("moving watchers failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	res.Votes, err = qtx.MoveBugVotes(r.Context(), database.MoveBugVotesParams{FromBugID: source.ID, ToBugID: target.ID})
+	if err != nil {
+		logger.Error("moving votes failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	for _, bugID := range []uuid.UUID{source.ID, target.ID} {
+		if _, err := qtx.RecountBugVotes(r.Context(), bugID); err != nil {
+			logger.Error("recounting votes failed", "bugID", bugID, "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+			return
+		}
+	}
+
+	if err := qtx.DeleteDuplicateOfLink(r.Context(), source.ID); err != nil {
+		logger.Error("removing old duplicate link failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	_, err = qtx.CreateBugLink(r.Context(), database.CreateBugLinkParams{
+		SourceID:  source.ID,
+		TargetID:  target.ID,
+		LinkType:  "duplicate-of",
+		CreatedBy: userID,
+	})
+	if err != nil {
+		logger.Error("linking duplicate failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	if isValidTransition(source.Status, "closed") {
+		changed, err := changeBugStatus(r.Context(), qtx, source, "closed", resolutionDuplicate, userID)
+		if err != nil {
+			logger.Error("closing duplicate failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+			return
+		}
+		if !changed {
+			utils.RespondWithError(w, http.StatusConflict, "bug status was changed by someone else, retry")
+			return
+		}
+	} else if source.Resolution.String != resolutionDuplicate {
+		// already closed, so only its resolution changes
+		updated, err := qtx.SetBugResolution(r.Context(), database.SetBugResolutionParams{
+			ID:         source.ID,
+			Resolution: textValue(resolutionDuplicate),
+			Status:     source.Status,
+		})
+		if err != nil {
+			logger.Error("marking duplicate resolution failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+			return
+		}
+		if updated == 0 {
+			utils.RespondWithError(w, http.StatusConflict, "bug status was changed by someone else, retry")
+			return
+		}
+		err = recordBugChanges(r.Context(), qtx, source.ID, userID, []bugChange{
+			{"resolution", source.Resolution, textValue(resolutionDuplicate)},
+		})
+		if err != nil {
+			logger.Error("recording bug history failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+			return
+		}
+	}
+	err = qtx.SetBugMergedInto(r.Context(), database.SetBugMergedIntoParams{
+		ID:           source.ID,
+		MergedIntoID: uuid.NullUUID{UUID: target.ID, Valid: true},
+	})
+	if err != nil {
+		logger.Error("marking bug as merged failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	err = recordBugChanges(r.Context(), qtx, source.ID, userID, []bugChange{
+		{"merged_into", sql.NullString{}, textValue(target.Key)},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	err = recordBugChanges(r.Context(), qtx, target.ID, userID, []bugChange{
+		{"merged_from", sql.NullString{}, textValue(source.Key)},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot merge bugs")
+		return
+	}
+
+	merged, err := cfg.DB.GetBugsByID(r.Context(), target.ID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch merged bug")
+		return
+	}
+	res.Bug = toBugResponse(merged)
+	logger.Info("bugs merged", "comments", res.Comments, "attachments", res.Attachments, "watchers", res.Watchers, "votes", res.Votes)
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
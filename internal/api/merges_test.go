@@ -0,0 +1,205 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeBugHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	adminID := uuid.New()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-2").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(sourceID, "login broken", "again", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 1, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-1").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(targetID, "login broken", "first report", uuid.New(), time.Now(), time.Now(), "triaged", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 4, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(linkPathExistsQuery).WithArgs(targetID, "duplicate-of", sourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveComments :execrows`)).WithArgs(targetID, sourceID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveCommentMentions :exec`)).WithArgs(targetID, sourceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveAttachments :execrows`)).WithArgs(targetID, sourceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveBugWatchers :execrows`)).WithArgs(sourceID, targetID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveBugVotes :execrows`)).WithArgs(sourceID, targetID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: RecountBugVotes :one`)).WithArgs(sourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"vote_count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: RecountBugVotes :one`)).WithArgs(targetID).
+		WillReturnRows(sqlmock.NewRows([]string{"vote_count"}).AddRow(5))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: DeleteDuplicateOfLink :exec`)).WithArgs(sourceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateBugLink :one`)).WithArgs(sourceID, targetID, "duplicate-of", adminID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "source_id", "target_id", "link_type", "created_by", "created_at"}).
+			AddRow(uuid.New(), sourceID, targetID, "duplicate-of", adminID, time.Now()))
+	mock.ExpectExec(updateBugStatusQuery).
+		WithArgs(sourceID, "closed", "duplicate", "open").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bug_status_transitions`)).
+		WithArgs(sourceID, "open", "closed", adminID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bug_id", "from_status", "to_status", "changed_by", "changed_at"}).
+			AddRow(uuid.New(), sourceID, "open", "closed", adminID, time.Now()))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(sourceID, adminID, "status", "open", "closed").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(sourceID, adminID, "resolution", nil, "duplicate").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: SetBugMergedInto :exec`)).WithArgs(sourceID, targetID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(sourceID, adminID, "merged_into", nil, "BUG-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(targetID, adminID, "merged_from", nil, "BUG-2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(targetID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(targetID, "login broken", "first report", uuid.New(), time.Now(), time.Now(), "triaged", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 5, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/merge", cfg.MergeBugHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/BUG-2/merge", bytes.NewBufferString(`{"into":"BUG-1"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", adminID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response MergeBugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, "BUG-2", response.MergedKey)
+	assert.Equal(t, targetID, response.Bug.ID)
+	assert.Equal(t, int32(5), response.Bug.Votes)
+	assert.Equal(t, int64(3), response.Comments)
+	assert.Equal(t, int64(1), response.Attachments)
+	assert.Equal(t, int64(2), response.Watchers)
+	assert.Equal(t, int64(1), response.Votes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeBugHandlerMarksClosedSourceDuplicate(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	adminID := uuid.New()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-2").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(sourceID, "login broken", "again", uuid.New(), time.Now(), time.Now(), "closed", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 0, nil, nil, "fixed", 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-1").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(targetID, "login broken", "first report", uuid.New(), time.Now(), time.Now(), "triaged", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(linkPathExistsQuery).WithArgs(targetID, "duplicate-of", sourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveComments :execrows`)).WithArgs(targetID, sourceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveCommentMentions :exec`)).WithArgs(targetID, sourceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveAttachments :execrows`)).WithArgs(targetID, sourceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveBugWatchers :execrows`)).WithArgs(sourceID, targetID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: MoveBugVotes :execrows`)).WithArgs(sourceID, targetID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: RecountBugVotes :one`)).WithArgs(sourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"vote_count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: RecountBugVotes :one`)).WithArgs(targetID).
+		WillReturnRows(sqlmock.NewRows([]string{"vote_count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: DeleteDuplicateOfLink :exec`)).WithArgs(sourceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateBugLink :one`)).WithArgs(sourceID, targetID, "duplicate-of", adminID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "source_id", "target_id", "link_type", "created_by", "created_at"}).
+			AddRow(uuid.New(), sourceID, targetID, "duplicate-of", adminID, time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: SetBugResolution :execrows`)).
+		WithArgs(sourceID, "duplicate", "closed").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(sourceID, adminID, "resolution", "fixed", "duplicate").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: SetBugMergedInto :exec`)).WithArgs(sourceID, targetID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(sourceID, adminID, "merged_into", nil, "BUG-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(targetID, adminID, "merged_from", nil, "BUG-2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(targetID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(targetID, "login broken", "first report", uuid.New(), time.Now(), time.Now(), "triaged", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/merge", cfg.MergeBugHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/BUG-2/merge", bytes.NewBufferString(`{"into":"BUG-1"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", adminID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeBugHandlerRejectsMergedTarget(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-3").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "crash", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 3, "BUG-3", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("BUG-2").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "crash", "", uuid.New(), time.Now(), time.Now(), "closed", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 0, nil, nil, "duplicate", 0, nil, uuid.New(), false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/merge", cfg.MergeBugHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/BUG-3/merge", bytes.NewBufferString(`{"into":"BUG-2"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", uuid.New()))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugByIDHandlerRedirectsMergedBug(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	targetID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "crash", "", uuid.New(), time.Now(), time.Now(), "closed", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 0, nil, nil, "duplicate", 0, nil, targetID, false))
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(targetID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(targetID, "crash on start", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}", cfg.GetBugByIDHandler)
+	req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String(), nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/api/bugs/"+targetID.String(), w.Header().Get("Location"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,418 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+var validMilestoneStates = map[string]bool{
+	"open":   true,
+	"closed": true,
+}
+
+type CreateMilestoneRequest struct {
+	Name    string `json:"name" example:"v1.4"`
+	DueDate string `json:"due_date" example:"2025-03-31"`
+}
+
+type UpdateMilestoneRequest struct {
+	Name    *string `json:"name" example:"v1.4"`
+	DueDate *string `json:"due_date" example:"2025-03-31"`
+	State   *string `json:"state" example:"closed"`
+}
+
+type SetBugMilestoneRequest struct {
+	MilestoneID uuid.UUID `json:"milestone_id" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"`
+}
+
+type MilestoneResponse struct {
+	ID        uuid.UUID `json:"id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Name      string    `json:"name" example:"v1.4"`
+	DueDate   *string   `json:"due_date" example:"2025-03-31"`
+	State     string    `json:"state" example:"open"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MilestoneProgressResponse counts resolved bugs as closed: a release waits on the fixes,
+// not on their verification.
+type MilestoneProgressResponse struct {
+	MilestoneResponse
+	OpenBugs        int64 `json:"open_bugs" example:"3"`
+	ClosedBugs      int64 `json:"closed_bugs" example:"9"`
+	PercentComplete int   `json:"percent_complete" example:"75"`
+}
+
+func toMilestoneResponse(milestone database.Milestone) MilestoneResponse {
+	res := MilestoneResponse{
+		ID:        milestone.ID,
+		ProjectID: milestone.ProjectID,
+		Name:      milestone.Name,
+		State:     milestone.State,
+		CreatedAt: milestone.CreatedAt,
+		UpdatedAt: milestone.UpdatedAt,
+	}
+	if milestone.DueDate.Valid {
+		due := milestone.DueDate.Time.Format(dateLayout)
+		res.DueDate = &due
+	}
+	return res
+}
+
+func parseMilestoneDate(s string) (sql.NullTime, error) {
+	if s == "" {
+		return sql.NullTime{}, nil
+	}
+	due, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	return sql.NullTime{Time: due, Valid: true}, nil
+}
+
+// milestoneFromPath loads the milestone addressed by the {id} path value. It writes the
+// error response itself when it returns false.
+func (cfg *APIConfig) milestoneFromPath(w http.ResponseWriter, r *http.Request) (database.Milestone, bool) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format milestone Id")
+		return database.Milestone{}, false
+	}
+	milestone, err := cfg.DB.GetMilestoneByID(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "no milestone found with the id")
+		return database.Milestone{}, false
+	}
+	if err != nil {
+		slog.Error("fetching milestone failed", "milestoneID", id, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch milestone")
+		return database.Milestone{}, false
+	}
+	return milestone, true
+}
+
+// @Summary Create a milestone
+// @Description Admins can define milestones, e.g. target releases, within a project
+// @Tags milestones
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Param request body CreateMilestoneRequest true "due_date is optional, formatted YYYY-MM-DD"
+// @Success 201 {object} MilestoneResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Milestone name already taken"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/milestones [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateMilestoneHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateMilestoneHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	var req CreateMilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "name field required")
+		return
+	}
+	due, err := parseMilestoneDate(req.DueDate)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "due_date must be formatted YYYY-MM-DD")
+		return
+	}
+
+	milestone, err := cfg.DB.CreateMilestone(r.Context(), database.CreateMilestoneParams{
+		ProjectID: project.ID,
+		Name:      req.Name,
+		DueDate:   due,
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "milestone name already taken in this project")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create milestone")
+		return
+	}
+	logger.Info("milestone created", "milestoneID", milestone.ID, "project", project.Key)
+	utils.RespondWithJSON(w, http.StatusCreated, toMilestoneResponse(milestone))
+}
+
+// @Summary List milestones of a project
+// @Description Returns the milestones of the project, soonest due first
+// @Tags milestones
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Success 200 {array} MilestoneResponse
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/milestones [get]
+func (cfg *APIConfig) GetProjectMilestonesHandler(w http.ResponseWriter, r *http.Request) {
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	milestones, err := cfg.DB.GetMilestonesByProject(r.Context(), project.ID)
+	if err != nil {
+		slog.Error("fetching milestones failed", "project", project.Key, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch milestones")
+		return
+	}
+	res := make([]MilestoneResponse, 0, len(milestones))
+	for _, milestone := range milestones {
+		res = append(res, toMilestoneResponse(milestone))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Get a milestone and its progress
+// @Description Returns the milestone with the number of open and closed bugs attached to it.
+// @Description Resolved bugs count as closed. A milestone without bugs is 100 percent complete.
+// @Tags milestones
+// @Produce json
+// @Param id path string true "Milestone ID" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Success 200 {object} MilestoneProgressResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /milestones/{id} [get]
+func (cfg *APIConfig) GetMilestoneHandler(w http.ResponseWriter, r *http.Request) {
+	milestone, ok := cfg.milestoneFromPath(w, r)
+	if !ok {
+		return
+	}
+	progress, err := cfg.DB.GetMilestoneProgress(r.Context(), uuid.NullUUID{UUID: milestone.ID, Valid: true})
+	if err != nil {
+		slog.Error("counting milestone bugs failed", "milestoneID", milestone.ID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch milestone")
+		return
+	}
+	percent := 100
+	if total := progress.OpenCount + progress.ClosedCount; total > 0 {
+		percent = int(progress.ClosedCount * 100 / total)
+	}
+	utils.RespondWithJSON(w, http.StatusOK, MilestoneProgressResponse{
+		MilestoneResponse: toMilestoneResponse(milestone),
+		OpenBugs:          progress.OpenCount,
+		ClosedBugs:        progress.ClosedCount,
+		PercentComplete:   percent,
+	})
+}
+
+// @Summary Update a milestone
+// @Description Admins can rename a milestone, move its due date or open and close it
+// @Tags milestones
+// @Accept json
+// @Produce json
+// @Param id path string true "Milestone ID" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param request body UpdateMilestoneRequest true "state is open or closed"
+// @Success 200 {object} MilestoneResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Milestone name already taken"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /milestones/{id} [put]
+// @Security BearerAuth
+func (cfg *APIConfig) UpdateMilestoneHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UpdateMilestoneHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	milestone, ok := cfg.milestoneFromPath(w, r)
+	if !ok {
+		return
+	}
+	var req UpdateMilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			utils.RespondWithError(w, http.StatusBadRequest, "name cannot be empty")
+			return
+		}
+		req.Name = &name
+	}
+	if req.State != nil && !validMilestoneStates[*req.State] {
+		utils.RespondWithError(w, http.StatusBadRequest, "state must be open or closed")
+		return
+	}
+	due := sql.NullTime{}
+	if req.DueDate != nil {
+		var err error
+		due, err = parseMilestoneDate(*req.DueDate)
+		if err != nil || !due.Valid {
+			utils.RespondWithError(w, http.StatusBadRequest, "due_date must be formatted YYYY-MM-DD")
+			return
+		}
+	}
+
+	updated, err := cfg.DB.UpdateMilestone(r.Context(), database.UpdateMilestoneParams{
+		ID:      milestone.ID,
+		Name:    toNullString(req.Name),
+		DueDate: due,
+		State:   toNullString(req.State),
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "milestone name already taken in this project")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update milestone")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toMilestoneResponse(updated))
+}
+
+// @Summary Put a bug on a milestone
+// @Description The author, the assignee or an admin can target a bug at an open milestone of its project
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param request body SetBugMilestoneRequest true "milestone to target"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Milestone is closed"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/milestone [put]
+// @Security BearerAuth
+func (cfg *APIConfig) SetBugMilestoneHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "SetBugMilestoneHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	var req SetBugMilestoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("given request body in wrong format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.MilestoneID == uuid.Nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "milestone_id field required")
+		return
+	}
+	cfg.setBugMilestone(w, r, logger, uuid.NullUUID{UUID: req.MilestoneID, Valid: true})
+}
+
+// @Summary Take a bug off its milestone
+// @Description The author, the assignee or an admin can remove the bug from its milestone
+// @Tags bugs
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/milestone [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) ClearBugMilestoneHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "ClearBugMilestoneHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	cfg.setBugMilestone(w, r, logger, uuid.NullUUID{})
+}
+
+func (cfg *APIConfig) setBugMilestone(w http.ResponseWriter, r *http.Request, logger *slog.Logger, milestoneID uuid.NullUUID) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	if !canManageBug(bug, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only author, assignee or admin can change the milestone")
+		return
+	}
+	if milestoneID.Valid {
+		milestone, err := cfg.DB.GetMilestoneByID(r.Context(), milestoneID.UUID)
+		if err != nil || milestone.ProjectID != bug.ProjectID {
+			utils.RespondWithError(w, http.StatusNotFound, "no milestone found with the id in the bug's project")
+			return
+		}
+		if milestone.State != "open" {
+			utils.RespondWithError(w, http.StatusConflict, "milestone "+milestone.Name+" is closed")
+			return
+		}
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change milestone")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	err = qtx.SetBugMilestone(r.Context(), database.SetBugMilestoneParams{
+		ID:          bug.ID,
+		MilestoneID: milestoneID,
+	})
+	if err != nil {
+		logger.Error("updating milestone failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change milestone")
+		return
+	}
+	err = recordBugChanges(r.Context(), qtx, bug.ID, userID, []bugChange{
+		{"milestone_id", uuidValue(bug.MilestoneID), uuidValue(milestoneID)},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change milestone")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change milestone")
+		return
+	}
+	bug.MilestoneID = milestoneID
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponse(bug))
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var milestoneColumns = []string{"id", "project_id", "name", "due_date", "state", "created_at", "updated_at"}
+
+func TestGetMilestoneHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	milestoneID := uuid.New()
+	due := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetMilestoneByID :one`)).WithArgs(milestoneID).
+		WillReturnRows(sqlmock.NewRows(milestoneColumns).
+			AddRow(milestoneID, testProjectID, "v1.4", due, "open", time.Now(), time.Now()))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetMilestoneProgress :one`)).WithArgs(milestoneID).
+		WillReturnRows(sqlmock.NewRows([]string{"open_count", "closed_count"}).AddRow(1, 2))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/milestones/{id}", cfg.GetMilestoneHandler)
+	req := httptest.NewRequest("GET", "/api/milestones/"+milestoneID.String(), nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response MilestoneProgressResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, "v1.4", response.Name)
+	if assert.NotNil(t, response.DueDate) {
+		assert.Equal(t, "2025-03-31", *response.DueDate)
+	}
+	assert.Equal(t, int64(1), response.OpenBugs)
+	assert.Equal(t, int64(2), response.ClosedBugs)
+	assert.Equal(t, 66, response.PercentComplete)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetBugMilestoneHandlerRejectsClosedMilestone(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	milestoneID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetMilestoneByID :one`)).WithArgs(milestoneID).
+		WillReturnRows(sqlmock.NewRows(milestoneColumns).
+			AddRow(milestoneID, testProjectID, "v1.3", nil, "closed", time.Now(), time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/milestone", cfg.SetBugMilestoneHandler)
+	body := bytes.NewBufferString(`{"milestone_id":"` + milestoneID.String() + `"}`)
+	req := httptest.NewRequest("PUT", "/api/bugs/"+bugID.String()+"/milestone", body)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
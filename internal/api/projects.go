@@ -0,0 +1,305 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// Project keys prefix every bug key, so they stay short, upper case and never change.
+var projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+
+type CreateProjectRequest struct {
+	Key         string `json:"key" example:"API"`
+	Name        string `json:"name" example:"Public API"`
+	Description string `json:"description" example:"Everything behind api.example.com"`
+}
+
+type UpdateProjectRequest struct {
+	Name        *string    `json:"name" example:"Public API"`
+	Description *string    `json:"description" example:"Everything behind api.example.com"`
+	OwnerID     *uuid.UUID `json:"owner_id" example:"9b733930-ef6f-4b01-add2-f410962ec695"`
+}
+
+type ProjectResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Key         string     `json:"key"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	OwnerID     *uuid.UUID `json:"owner_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func toProjectResponse(project database.Project) ProjectResponse {
+	res := ProjectResponse{
+		ID:          project.ID,
+		Key:         project.Key,
+		Name:        project.Name,
+		Description: project.Description,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+	if project.OwnerID.Valid {
+		res.OwnerID = &project.OwnerID.UUID
+	}
+	return res
+}
+
+// canManageProject reports whether the user may change the project and what belongs
+// to it. Projects without an owner, like the default project of a fresh install, are
+// managed by admins only.
+func canManageProject(project database.Project, userID uuid.UUID, role string) bool {
+	return role == "admin" || (project.OwnerID.Valid && project.OwnerID.UUID == userID)
+}
+
+// projectFromPath loads the project addressed by the {key} path value. It writes the
+// error response itself when it returns false.
+func (cfg *APIConfig) projectFromPath(w http.ResponseWriter, r *http.Request) (database.Project, bool) {
+	key := r.PathValue("key")
+	project, err := cfg.DB.GetProjectByKey(r.Context(), key)
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "no project found with the key")
+		return database.Project{}, false
+	}
+	if err != nil {
+		slog.Error("fetching project failed", "key", key, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch project")
+		return database.Project{}, false
+	}
+	return project, true
+}
+
+// @Summary Create a project
+// @Description Logged in users can create a project, which they own
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param request body CreateProjectRequest true "project creation data"
+// @Success 201 {object} ProjectResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Project key already taken"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateProjectHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateProjectHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	var req CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if !projectKeyPattern.MatchString(req.Key) {
+		utils.RespondWithError(w, http.StatusBadRequest, "key must be 2-10 upper case letters or digits, starting with a letter")
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "name field required")
+		return
+	}
+
+	project, err := cfg.DB.CreateProject(r.Context(), database.CreateProjectParams{
+		Key:         req.Key,
+		Name:        req.Name,
+		Description: req.Description,
+		OwnerID:     uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "project key already taken")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create project")
+		return
+	}
+	logger.Info("project created", "projectID", project.ID, "key", project.Key)
+	utils.RespondWithJSON(w, http.StatusCreated, toProjectResponse(project))
+}
+
+// @Summary List projects
+// @Description Returns every project ordered by key
+// @Tags projects
+// @Produce json
+// @Success 200 {array} ProjectResponse
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects [get]
+func (cfg *APIConfig) GetProjectsHandler(w http.ResponseWriter, r *http.Request) {
+	projects, err := cfg.DB.GetAllProjects(r.Context())
+	if err != nil {
+		slog.Error("fetching projects failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch projects")
+		return
+	}
+	res := make([]ProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		res = append(res, toProjectResponse(project))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Get a project
+// @Tags projects
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Success 200 {object} ProjectResponse
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key} [get]
+func (cfg *APIConfig) GetProjectHandler(w http.ResponseWriter, r *http.Request) {
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toProjectResponse(project))
+}
+
+// @Summary Update a project
+// @Description The project owner or an admin can rename a project or hand it over; the key never changes
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Param request body UpdateProjectRequest true "project updation data"
+// @Success 200 {object} ProjectResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key} [put]
+// @Security BearerAuth
+func (cfg *APIConfig) UpdateProjectHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UpdateProjectHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	if !canManageProject(project, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only project owner or admin can update the project")
+		return
+	}
+
+	var req UpdateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "name cannot be empty")
+		return
+	}
+	ownerID := uuid.NullUUID{}
+	if req.OwnerID != nil {
+		if _, err := cfg.DB.GetUserByID(r.Context(), *req.OwnerID); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "new owner does not exist")
+			return
+		}
+		ownerID = uuid.NullUUID{UUID: *req.OwnerID, Valid: true}
+	}
+
+	updated, err := cfg.DB.UpdateProject(r.Context(), database.UpdateProjectParams{
+		ID:          project.ID,
+		Name:        toNullString(req.Name),
+		Description: toNullString(req.Description),
+		OwnerID:     ownerID,
+	})
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update project")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toProjectResponse(updated))
+}
+
+// @Summary List bugs of a project
+// @Description Same filters and sort options as GET /bugs, limited to one project
+// @Tags projects
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Param severity query []string false "Only bugs with these severities" collectionFormat(multi)
+// @Param priority query []string false "Only bugs with these priorities" collectionFormat(multi)
+// @Param label query []string false "Only bugs carrying all of these labels" collectionFormat(multi)
+// @Param sort query string false "Sort order: created (default), priority, severity or votes"
+// @Success 200 {array} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/bugs [get]
+func (cfg *APIConfig) GetProjectBugsHandler(w http.ResponseWriter, r *http.Request) {
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	cfg.listBugs(w, r, uuid.NullUUID{UUID: project.ID, Valid: true})
+}
+
+// @Summary Create a bug in a project
+// @Description Existing users can file a bug directly under a project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Param request body CreateBugRequest true "bug creation data"
+// @Success 201 {object} CreateBugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/bugs [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateProjectBugHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateProjectBugHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	var req CreateBugRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	cfg.createBug(w, r, logger, userID, project, req)
+}
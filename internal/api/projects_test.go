@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateProjectHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	expectedQuery := `-- name: CreateProject :one
+INSERT INTO projects (id, key, name, description, owner_id, created_at, updated_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW(),
+    NOW()
+)
+RETURNING id, key, name, description, owner_id, created_at, updated_at, last_bug_number`
+	mock.ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+		WithArgs("API", "Public API", "", userID).
+		WillReturnRows(sqlmock.NewRows(projectColumns).
+			AddRow(projectID, "API", "Public API", "", userID, time.Now(), time.Now(), 0))
+
+	req := httptest.NewRequest("POST", "/api/projects", bytes.NewBufferString(`{"key":"API","name":"Public API"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	cfg.CreateProjectHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response ProjectResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, projectID, response.ID)
+	if assert.NotNil(t, response.OwnerID) {
+		assert.Equal(t, userID, *response.OwnerID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProjectHandlerRejectsBadKey(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	req := httptest.NewRequest("POST", "/api/projects", bytes.NewBufferString(`{"key":"api-v2","name":"Public API"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", uuid.New()))
+	w := httptest.NewRecorder()
+	cfg.CreateProjectHandler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProjectBugsHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	projectID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetProjectByKey :one`)).WithArgs("API").
+		WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(projectID, "API", "Public API", "", uuid.New(), time.Now(), time.Now(), 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: ListBugs :many`)).
+		WithArgs(nil, nil, nil, projectID, nil, nil, nil, false, nil, "created").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "timeout", "504 on /users", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, projectID, 7, "API-7", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/projects/{key}/bugs", cfg.GetProjectBugsHandler)
+	req := httptest.NewRequest("GET", "/api/projects/API/bugs", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.Len(t, response, 1) {
+		assert.Equal(t, projectID, response[0].ProjectID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetProjectHandlerNotFound(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetProjectByKey :one`)).WithArgs("NOPE").
+		WillReturnRows(sqlmock.NewRows(projectColumns))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/projects/{key}", cfg.GetProjectHandler)
+	req := httptest.NewRequest("GET", "/api/projects/NOPE", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCanManageProjectWithoutOwner(t *testing.T) {
+	userID := uuid.New()
+	project := database.Project{ID: uuid.New(), Key: defaultProjectKey}
+
+	assert.False(t, canManageProject(project, userID, "user"))
+	assert.True(t, canManageProject(project, userID, "admin"))
+
+	project.OwnerID = uuid.NullUUID{UUID: userID, Valid: true}
+	assert.True(t, canManageProject(project, userID, "user"))
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	resolutionFixed           = "fixed"
+	resolutionWontFix         = "wont_fix"
+	resolutionDuplicate       = "duplicate"
+	resolutionCannotReproduce = "cannot_reproduce"
+	resolutionWorksAsIntended = "works_as_intended"
+)
+
+var validResolutions = map[string]bool{
+	resolutionFixed:           true,
+	resolutionWontFix:         true,
+	resolutionDuplicate:       true,
+	resolutionCannotReproduce: true,
+	resolutionWorksAsIntended: true,
+}
+
+// resolvedStatuses are the statuses in which a bug carries a resolution.
+var resolvedStatuses = map[string]bool{
+	"resolved": true,
+	"closed":   true,
+}
+
+const (
+	defaultReopenedLimit = 20
+	maxReopenedLimit     = 100
+)
+
+// @Summary Most reopened bugs
+// @Description Lists bugs that were reopened at least once, most reopened first. Fixes that do not hold show up here.
+// @Tags reports
+// @Produce json
+// @Param project query string false "Only bugs of the project with this key"
+// @Param limit query int false "Number of bugs, at most 100" default(20)
+// @Success 200 {array} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /reports/reopened [get]
+// @Security BearerAuth
+func (cfg *APIConfig) GetMostReopenedBugsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit := defaultReopenedLimit
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxReopenedLimit {
+			utils.RespondWithError(w, http.StatusBadRequest, "limit must be a number from 1 to 100")
+			return
+		}
+		limit = n
+	}
+	projectID := uuid.NullUUID{}
+	if key := query.Get("project"); key != "" {
+		project, err := cfg.DB.GetProjectByKey(r.Context(), key)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "unknown project: "+key)
+			return
+		}
+		projectID = uuid.NullUUID{UUID: project.ID, Valid: true}
+	}
+
+	viewer, role := viewerFromContext(r.Context())
+	bugs, err := cfg.DB.GetMostReopenedBugs(r.Context(), database.GetMostReopenedBugsParams{
+		ProjectID:       projectID,
+		SeeConfidential: seesConfidentialBugs(role),
+		ViewerID:        viewer,
+		MaxResults:      int32(limit),
+	})
+	if err != nil {
+		slog.Error("fetching most reopened bugs failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt build report")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponses(bugs))
+}
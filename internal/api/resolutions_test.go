@@ -0,0 +1,172 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionBugStatusHandlerRequiresResolution(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "in_progress", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/status", cfg.TransitionBugStatusHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/status", bytes.NewBufferString(`{"status":"closed"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	for _, body := range []string{`{"status":"closed","resolution":"done"}`, `{"status":"triaged","resolution":"fixed"}`} {
+		req = httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/status", bytes.NewBufferString(body))
+		req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestTransitionBugStatusHandlerClosesWithResolution(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "in_progress", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectExec(updateBugStatusQuery).
+		WithArgs(bugID, "closed", "wont_fix", "in_progress").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bug_status_transitions`)).
+		WithArgs(bugID, "in_progress", "closed", userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bug_id", "from_status", "to_status", "changed_by", "changed_at"}).
+			AddRow(uuid.New(), bugID, "in_progress", "closed", userID, time.Now()))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "status", "in_progress", "closed").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "resolution", nil, "wont_fix").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "closed", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, "wont_fix", 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/status", cfg.TransitionBugStatusHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/status", bytes.NewBufferString(`{"status":"closed","resolution":"wont_fix"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.NotNil(t, response.Resolution) {
+		assert.Equal(t, "wont_fix", *response.Resolution)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransitionBugStatusHandlerReopenClearsResolution(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	reopenedAt := time.Now().UTC()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "closed", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, "fixed", 1, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectExec(updateBugStatusQuery).
+		WithArgs(bugID, "reopened", nil, "closed").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bug_status_transitions`)).
+		WithArgs(bugID, "closed", "reopened", userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "bug_id", "from_status", "to_status", "changed_by", "changed_at"}).
+			AddRow(uuid.New(), bugID, "closed", "reopened", userID, time.Now()))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "status", "closed", "reopened").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "resolution", "fixed", nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", userID, time.Now(), time.Now(), "reopened", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 2, reopenedAt, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/status", cfg.TransitionBugStatusHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/status", bytes.NewBufferString(`{"status":"reopened"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Nil(t, response.Resolution)
+	assert.Equal(t, int32(2), response.ReopenCount)
+	if assert.NotNil(t, response.LastReopenedAt) {
+		assert.WithinDuration(t, reopenedAt, *response.LastReopenedAt, time.Second)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMostReopenedBugsHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetMostReopenedBugs :many`)).WithArgs(nil, false, nil, 5).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "flaky login", "", uuid.New(), time.Now(), time.Now(), "reopened", "major", "P1", nil, testProjectID, 7, "BUG-7", nil, nil, nil, 0, nil, nil, nil, 4, time.Now(), nil, false).
+			AddRow(uuid.New(), "cache stale", "", uuid.New(), time.Now(), time.Now(), "closed", "minor", "P3", nil, testProjectID, 3, "BUG-3", nil, nil, nil, 0, nil, nil, "fixed", 2, time.Now(), nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/reports/reopened", cfg.GetMostReopenedBugsHandler)
+	req := httptest.NewRequest("GET", "/api/reports/reopened?limit=5", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.Len(t, response, 2) {
+		assert.Equal(t, "BUG-7", response[0].Key)
+		assert.Equal(t, int32(4), response[0].ReopenCount)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	req = httptest.NewRequest("GET", "/api/reports/reopened?limit=500", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
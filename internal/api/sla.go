@@ -0,0 +1,297 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	slaOK       = "ok"
+	slaAtRisk   = "at_risk"
+	slaBreached = "breached"
+)
+
+var validSlaStates = map[string]bool{
+	slaOK:       true,
+	slaAtRisk:   true,
+	slaBreached: true,
+}
+
+type SlaPolicyResponse struct {
+	Priority           string    `json:"priority" example:"P0"`
+	ResolveWithinHours int32     `json:"resolve_within_hours" example:"24"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type SetSlaPolicyRequest struct {
+	ResolveWithinHours int32 `json:"resolve_within_hours" example:"24"`
+}
+
+type SetBugDueRequest struct {
+	DueAt time.Time `json:"due_at" example:"2025-07-01T17:00:00Z"`
+}
+
+func toSlaPolicyResponse(policy database.SlaPolicy) SlaPolicyResponse {
+	return SlaPolicyResponse{
+		Priority:           policy.Priority,
+		ResolveWithinHours: policy.ResolveWithinHours,
+		UpdatedAt:          policy.UpdatedAt,
+	}
+}
+
+// slaDeadline is when the bug has to be resolved: its own due date if it has one,
+// otherwise the time its priority's policy allows from filing. ok is false when
+// neither applies.
+func slaDeadline(bug database.Bug, policy *database.SlaPolicy) (deadline time.Time, ok bool) {
+	if bug.DueAt.Valid {
+		return bug.DueAt.Time, true
+	}
+	if policy != nil {
+		return bug.CreatedAt.Add(time.Duration(policy.ResolveWithinHours) * time.Hour), true
+	}
+	return time.Time{}, false
+}
+
+// slaState tells how an unresolved bug stands against its deadline at now. A bug
+// is at risk once less than a fifth of the time it was given is left. Resolved and
+// closed bugs and bugs without a deadline have no state. ListBugs filters on the
+// same rules in SQL.
+func slaState(bug database.Bug, policy *database.SlaPolicy, now time.Time) string {
+	if bug.Status == "resolved" || bug.Status == "closed" {
+		return ""
+	}
+	deadline, ok := slaDeadline(bug, policy)
+	if !ok {
+		return ""
+	}
+	if !now.Before(deadline) {
+		return slaBreached
+	}
+	if !now.Before(deadline.Add(-deadline.Sub(bug.CreatedAt) / 5)) {
+		return slaAtRisk
+	}
+	return slaOK
+}
+
+// @Summary List SLA policies
+// @Description Returns how many hours bugs of each priority may stay unresolved. Priorities without a policy have no SLA.
+// @Tags sla
+// @Produce json
+// @Success 200 {array} SlaPolicyResponse
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /sla [get]
+func (cfg *APIConfig) GetSlaPoliciesHandler(w http.ResponseWriter, r *http.Request) {
+	policies, err := cfg.DB.GetSlaPolicies(r.Context())
+	if err != nil {
+		slog.Error("fetching sla policies failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch sla policies")
+		return
+	}
+	res := make([]SlaPolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		res = append(res, toSlaPolicyResponse(policy))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Set the SLA of a priority
+// @Description Admins can set how many hours bugs of a priority may stay unresolved, e.g. 24 for P0
+// @Tags sla
+// @Accept json
+// @Produce json
+// @Param priority path string true "Priority" example:"P0"
+// @Param request body SetSlaPolicyRequest true "resolution time"
+// @Success 200 {object} SlaPolicyResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /sla/{priority} [put]
+// @Security BearerAuth
+func (cfg *APIConfig) SetSlaPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "SetSlaPolicyHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	priority := r.PathValue("priority")
+	if !validPriorities[priority] {
+		utils.RespondWithError(w, http.StatusBadRequest, "priority must be one of P0, P1, P2, P3, P4")
+		return
+	}
+	var req SetSlaPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("given request body in wrong format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.ResolveWithinHours <= 0 {
+		utils.RespondWithError(w, http.StatusBadRequest, "resolve_within_hours must be positive")
+		return
+	}
+	policy, err := cfg.DB.UpsertSlaPolicy(r.Context(), database.UpsertSlaPolicyParams{
+		Priority:           priority,
+		ResolveWithinHours: req.ResolveWithinHours,
+	})
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot set sla policy")
+		return
+	}
+	logger.Info("sla policy set", "priority", priority, "hours", policy.ResolveWithinHours)
+	utils.RespondWithJSON(w, http.StatusOK, toSlaPolicyResponse(policy))
+}
+
+// @Summary Remove the SLA of a priority
+// @Description Admins can drop the policy of a priority. Bugs with their own due date keep it.
+// @Tags sla
+// @Param priority path string true "Priority" example:"P0"
+// @Success 204 {string} string "No content"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /sla/{priority} [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) DeleteSlaPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	priority := r.PathValue("priority")
+	deleted, err := cfg.DB.DeleteSlaPolicy(r.Context(), priority)
+	if err != nil {
+		slog.Error("deleting sla policy failed", "priority", priority, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete sla policy")
+		return
+	}
+	if deleted == 0 {
+		utils.RespondWithError(w, http.StatusNotFound, "no sla policy for priority "+priority)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Set the due date of a bug
+// @Description The author, the assignee or an admin can give a bug its own deadline. It takes precedence over the SLA of the bug's priority.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param request body SetBugDueRequest true "due date"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/due [put]
+// @Security BearerAuth
+func (cfg *APIConfig) SetBugDueHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "SetBugDueHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	var req SetBugDueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("given request body in wrong format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.DueAt.IsZero() {
+		utils.RespondWithError(w, http.StatusBadRequest, "due_at field required")
+		return
+	}
+	cfg.setBugDue(w, r, logger, sql.NullTime{Time: req.DueAt.UTC(), Valid: true})
+}
+
+// @Summary Clear the due date of a bug
+// @Description The author, the assignee or an admin can remove the bug's own deadline, so the SLA of its priority applies again
+// @Tags bugs
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/due [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) ClearBugDueHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "ClearBugDueHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	cfg.setBugDue(w, r, logger, sql.NullTime{})
+}
+
+func (cfg *APIConfig) setBugDue(w http.ResponseWriter, r *http.Request, logger *slog.Logger, dueAt sql.NullTime) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	if !canManageBug(bug, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only author, assignee or admin can change the due date")
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change due date")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	err = qtx.SetBugDueAt(r.Context(), database.SetBugDueAtParams{
+		ID:    bug.ID,
+		DueAt: dueAt,
+	})
+	if err != nil {
+		logger.Error("updating due date failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change due date")
+		return
+	}
+	err = recordBugChanges(r.Context(), qtx, bug.ID, userID, []bugChange{
+		{"due_at", timeValue(bug.DueAt), timeValue(dueAt)},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change due date")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change due date")
+		return
+	}
+	bug.DueAt = dueAt
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponse(bug))
+}
+
+// bugSlaPolicy returns the SLA policy of the bug's priority, or nil if it has none.
+func (cfg *APIConfig) bugSlaPolicy(r *http.Request, bug database.Bug) (*database.SlaPolicy, error) {
+	policy, err := cfg.DB.GetSlaPolicy(r.Context(), bug.Priority)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
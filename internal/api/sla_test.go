@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var slaPolicyColumns = []string{"priority", "resolve_within_hours", "updated_at"}
+
+var getSlaPolicyQuery = regexp.QuoteMeta(`-- name: GetSlaPolicy :one`)
+
+func TestSlaState(t *testing.T) {
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	policy := &database.SlaPolicy{Priority: "P0", ResolveWithinHours: 24}
+	bug := database.Bug{Status: "open", Priority: "P0", CreatedAt: created}
+
+	assert.Equal(t, slaOK, slaState(bug, policy, created.Add(10*time.Hour)))
+	assert.Equal(t, slaAtRisk, slaState(bug, policy, created.Add(20*time.Hour)))
+	assert.Equal(t, slaBreached, slaState(bug, policy, created.Add(24*time.Hour)))
+	assert.Equal(t, "", slaState(bug, nil, created.Add(48*time.Hour)))
+
+	bug.DueAt.Time, bug.DueAt.Valid = created.Add(100*time.Hour), true
+	assert.Equal(t, slaOK, slaState(bug, policy, created.Add(48*time.Hour)))
+
+	bug.Status = "resolved"
+	assert.Equal(t, "", slaState(bug, policy, created.Add(200*time.Hour)))
+}
+
+func TestSlaStateAcrossTimeZones(t *testing.T) {
+	// filed after midnight in Kolkata, which is still the previous day in UTC
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	created := time.Date(2025, 6, 2, 1, 0, 0, 0, kolkata)
+	policy := &database.SlaPolicy{Priority: "P0", ResolveWithinHours: 24}
+	bug := database.Bug{Status: "open", Priority: "P0", CreatedAt: created}
+
+	assert.Equal(t, slaOK, slaState(bug, policy, time.Date(2025, 6, 2, 14, 0, 0, 0, time.UTC)))
+	assert.Equal(t, slaAtRisk, slaState(bug, policy, time.Date(2025, 6, 3, 0, 30, 0, 0, kolkata)))
+	assert.Equal(t, slaBreached, slaState(bug, policy, time.Date(2025, 6, 2, 19, 30, 0, 0, time.UTC)))
+
+	// a due date given west of UTC is compared as the same instant
+	pacific := time.FixedZone("PDT", -7*60*60)
+	bug.DueAt.Time, bug.DueAt.Valid = time.Date(2025, 6, 2, 9, 30, 0, 0, pacific), true
+	assert.Equal(t, slaOK, slaState(bug, policy, time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, slaAtRisk, slaState(bug, policy, time.Date(2025, 6, 2, 9, 0, 0, 0, pacific)))
+	assert.Equal(t, slaBreached, slaState(bug, policy, time.Date(2025, 6, 2, 16, 30, 0, 0, time.UTC)))
+}
+
+func TestGetBugByIDHandlerReportsSlaBreach(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	created := time.Now().UTC().Add(-30 * time.Hour)
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "payments down", "", uuid.New(), created, created, "open", "blocker", "P0", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetLabelsByBug :many`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(labelColumns))
+	mock.ExpectQuery(getCanonicalBugQuery).WithArgs(bugID).WillReturnRows(sqlmock.NewRows(bugColumns))
+	mock.ExpectQuery(getBugFieldValuesQuery).WithArgs(bugID).WillReturnRows(sqlmock.NewRows(fieldValueColumns))
+	mock.ExpectQuery(getSlaPolicyQuery).WithArgs("P0").
+		WillReturnRows(sqlmock.NewRows(slaPolicyColumns).AddRow("P0", 24, time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}", cfg.GetBugByIDHandler)
+	req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String(), nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, "breached", response.SlaState)
+	if assert.NotNil(t, response.SlaDeadline) {
+		assert.WithinDuration(t, created.Add(24*time.Hour), *response.SlaDeadline, time.Second)
+	}
+	assert.Nil(t, response.DueAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugsHandlerFiltersBySla(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: ListBugs :many`)).
+		WithArgs(nil, nil, nil, nil, nil, nil, "breached", false, nil, "created").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "payments down", "", uuid.New(), time.Now(), time.Now(), "open", "blocker", "P0", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs", cfg.GetBugsHandler)
+	req := httptest.NewRequest("GET", "/api/bugs?sla=breached", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Len(t, response, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	req = httptest.NewRequest("GET", "/api/bugs?sla=late", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSetBugDueHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	due := time.Date(2025, 7, 1, 17, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "slow export", "", userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: SetBugDueAt :exec`)).WithArgs(bugID, due).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, userID, "due_at", nil, "2025-07-01T17:00:00Z").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/due", cfg.SetBugDueHandler)
+	req := httptest.NewRequest("PUT", "/api/bugs/"+bugID.String()+"/due", bytes.NewBufferString(`{"due_at":"2025-07-01T19:00:00+02:00"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BugResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.NotNil(t, response.DueAt) {
+		assert.True(t, due.Equal(*response.DueAt))
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// templateHeadingPattern matches a Markdown ATX heading. Every heading of a template's
+// description is a section a bug filed with the template has to fill in.
+var templateHeadingPattern = regexp.MustCompile(`^ {0,3}#{1,6}[ \t]+(.*?)[ \t#]*$`)
+
+// errInvalidTemplate marks template input the client has to correct.
+var errInvalidTemplate = errors.New("invalid template")
+
+type CreateBugTemplateRequest struct {
+	Name           string   `json:"name" example:"Crash report"`
+	Description    string   `json:"description" example:"## Steps to reproduce"`
+	Labels         []string `json:"labels" example:"crash"`
+	RequiredFields []string `json:"required_fields" example:"environment"`
+}
+
+type UpdateBugTemplateRequest struct {
+	Name           *string  `json:"name" example:"Crash report"`
+	Description    *string  `json:"description" example:"## Stack trace"`
+	Labels         []string `json:"labels" example:"crash"`
+	RequiredFields []string `json:"required_fields" example:"environment"`
+}
+
+type BugTemplateResponse struct {
+	ID             uuid.UUID `json:"id"`
+	ProjectID      uuid.UUID `json:"project_id"`
+	Name           string    `json:"name" example:"Crash report"`
+	Description    string    `json:"description"`
+	Sections       []string  `json:"sections" example:"Steps to reproduce,Expected result,Actual result"`
+	Labels         []string  `json:"labels"`
+	RequiredFields []string  `json:"required_fields"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func toBugTemplateResponse(template database.BugTemplate) BugTemplateResponse {
+	res := BugTemplateResponse{
+		ID:             template.ID,
+		ProjectID:      template.ProjectID,
+		Name:           template.Name,
+		Description:    template.Description,
+		Sections:       []string{},
+		Labels:         template.Labels,
+		RequiredFields: template.RequiredFields,
+		CreatedAt:      template.CreatedAt,
+		UpdatedAt:      template.UpdatedAt,
+	}
+	for _, section := range templateSections(template.Description) {
+		res.Sections = append(res.Sections, section.Title)
+	}
+	if res.Labels == nil {
+		res.Labels = []string{}
+	}
+	if res.RequiredFields == nil {
+		res.RequiredFields = []string{}
+	}
+	return res
+}
+
+type templateSection struct {
+	Title string
+	Body  string
+}
+
+// templateSections splits Markdown text at its headings. Text before the first heading
+// does not belong to a section.
+func templateSections(text string) []templateSection {
+	var sections []templateSection
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := templateHeadingPattern.FindStringSubmatch(line); m != nil && m[1] != "" {
+			sections = append(sections, templateSection{Title: m[1]})
+			continue
+		}
+		if len(sections) > 0 {
+			last := &sections[len(sections)-1]
+			last.Body += line + "\n"
+		}
+	}
+	for i := range sections {
+		sections[i].Body = strings.TrimSpace(sections[i].Body)
+	}
+	return sections
+}
+
+// missingTemplateSections returns the sections of the template that description leaves
+// out, leaves empty or still answers with the template's own placeholder text.
+func missingTemplateSections(template database.BugTemplate, description string) []string {
+	filled := make(map[string]string)
+	for _, section := range templateSections(description) {
+		filled[strings.ToLower(section.Title)] = section.Body
+	}
+	var missing []string
+	for _, section := range templateSections(template.Description) {
+		body, ok := filled[strings.ToLower(section.Title)]
+		if !ok || body == "" || body == section.Body {
+			missing = append(missing, section.Title)
+		}
+	}
+	return missing
+}
+
+// missingTemplateFields returns the required fields of the template that fields does not set.
+func missingTemplateFields(template database.BugTemplate, fields []fieldValueChange) []string {
+	set := make(map[string]bool, len(fields))
+	for _, change := range fields {
+		set[change.Field.Name] = change.Value.Valid
+	}
+	var missing []string
+	for _, name := range template.RequiredFields {
+		if !set[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// checkTemplateFilled answers whether a bug with description and fields may be filed
+// with the template. The error wraps errInvalidTemplate and says what is missing.
+func checkTemplateFilled(template database.BugTemplate, description string, fields []fieldValueChange) error {
+	if missing := missingTemplateSections(template, description); len(missing) > 0 {
+		return fmt.Errorf("%w: description must fill in the sections %s", errInvalidTemplate, strings.Join(missing, ", "))
+	}
+	if missing := missingTemplateFields(template, fields); len(missing) > 0 {
+		return fmt.Errorf("%w: the fields %s are required", errInvalidTemplate, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkTemplateReferences makes sure the default labels exist and the required fields
+// are custom fields of the project. Errors wrapping errInvalidTemplate are the client's.
+func (cfg *APIConfig) checkTemplateReferences(ctx context.Context, projectID uuid.UUID, labels, requiredFields []string) error {
+	if len(labels) > 0 {
+		known, err := cfg.DB.GetAllLabels(ctx)
+		if err != nil {
+			return err
+		}
+		names := make(map[string]bool, len(known))
+		for _, label := range known {
+			names[label.Name] = true
+		}
+		for _, label := range labels {
+			if !names[label] {
+				return fmt.Errorf("%w: unknown label %s", errInvalidTemplate, label)
+			}
+		}
+	}
+	if len(requiredFields) > 0 {
+		fields, err := cfg.DB.GetCustomFieldsByProject(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		names := make(map[string]bool, len(fields))
+		for _, field := range fields {
+			names[field.Name] = true
+		}
+		for _, name := range requiredFields {
+			if !names[name] {
+				return fmt.Errorf("%w: the project has no field %s", errInvalidTemplate, name)
+			}
+		}
+	}
+	return nil
+}
+
+// @Summary Define a bug template
+// @Description Admins can add a template to a project. Headings in the description are sections reporters have to fill in; labels are added to every bug filed with the template.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Param request body CreateBugTemplateRequest true "template data"
+// @Success 201 {object} BugTemplateResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Template name already taken"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/templates [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateBugTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateBugTemplateHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	var req CreateBugTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "name field required")
+		return
+	}
+	err := cfg.checkTemplateReferences(r.Context(), project.ID, req.Labels, req.RequiredFields)
+	if errors.Is(err, errInvalidTemplate) {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		logger.Error("checking template failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create template")
+		return
+	}
+
+	template, err := cfg.DB.CreateBugTemplate(r.Context(), database.CreateBugTemplateParams{
+		ProjectID:      project.ID,
+		Name:           req.Name,
+		Description:    req.Description,
+		Labels:         req.Labels,
+		RequiredFields: req.RequiredFields,
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "template name already taken in this project")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot create template")
+		return
+	}
+	logger.Info("bug template created", "templateID", template.ID, "project", project.Key)
+	utils.RespondWithJSON(w, http.StatusCreated, toBugTemplateResponse(template))
+}
+
+// @Summary List bug templates of a project
+// @Tags projects
+// @Produce json
+// @Param key path string true "Project key" example:"API"
+// @Success 200 {array} BugTemplateResponse
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /projects/{key}/templates [get]
+func (cfg *APIConfig) GetProjectBugTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	project, ok := cfg.projectFromPath(w, r)
+	if !ok {
+		return
+	}
+	templates, err := cfg.DB.GetBugTemplatesByProject(r.Context(), project.ID)
+	if err != nil {
+		slog.Error("fetching bug templates failed", "project", project.Key, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch templates")
+		return
+	}
+	res := make([]BugTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		res = append(res, toBugTemplateResponse(template))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Update a bug template
+// @Description Admins can rename a template or change its description, labels and required fields
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Param request body UpdateBugTemplateRequest true "template updation data"
+// @Success 200 {object} BugTemplateResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 409 {object} utils.ErrorResponse "Conflict - Template name already taken"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /templates/{id} [put]
+// @Security BearerAuth
+func (cfg *APIConfig) UpdateBugTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UpdateBugTemplateHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	templateID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format template Id")
+		return
+	}
+	var req UpdateBugTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "name cannot be empty")
+		return
+	}
+	template, err := cfg.DB.GetBugTemplateByID(r.Context(), templateID)
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "no template found with the id")
+		return
+	}
+	if err != nil {
+		logger.Error("fetching template failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update template")
+		return
+	}
+	err = cfg.checkTemplateReferences(r.Context(), template.ProjectID, req.Labels, req.RequiredFields)
+	if errors.Is(err, errInvalidTemplate) {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		logger.Error("checking template failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update template")
+		return
+	}
+
+	updated, err := cfg.DB.UpdateBugTemplate(r.Context(), database.UpdateBugTemplateParams{
+		ID:             templateID,
+		Name:           toNullString(req.Name),
+		Description:    toNullString(req.Description),
+		Labels:         req.Labels,
+		RequiredFields: req.RequiredFields,
+	})
+	if isUniqueViolation(err) {
+		utils.RespondWithError(w, http.StatusConflict, "template name already taken in this project")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update template")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toBugTemplateResponse(updated))
+}
+
+// @Summary Delete a bug template
+// @Description Admins can remove a template. Bugs filed with it are not changed.
+// @Tags projects
+// @Param id path string true "Template ID" example:"c1f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /templates/{id} [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) DeleteBugTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	templateID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format template Id")
+		return
+	}
+	deleted, err := cfg.DB.DeleteBugTemplate(r.Context(), templateID)
+	if err != nil {
+		slog.Error("deleting bug template failed", "templateID", templateID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete template")
+		return
+	}
+	if deleted == 0 {
+		utils.RespondWithError(w, http.StatusNotFound, "no template found with the id")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var bugTemplateColumns = []string{"id", "project_id", "name", "description", "labels", "required_fields", "created_at", "updated_at"}
+
+const crashTemplate = "Thanks for the report!\n\n## Steps to reproduce\n1.\n\n## Expected result\n\n## Actual result\n"
+
+func TestMissingTemplateSections(t *testing.T) {
+	template := database.BugTemplate{Description: crashTemplate}
+	description := "## Steps to reproduce\n1.\n\n## expected result\nThe app opens\n\n## Actual result\n"
+	assert.Equal(t, []string{"Steps to reproduce", "Actual result"}, missingTemplateSections(template, description))
+
+	description = "## Steps to reproduce\n1. open the app\n## Expected result\nit opens\n## Actual result\nit crashes"
+	assert.Empty(t, missingTemplateSections(template, description))
+}
+
+func TestCreateBugHandlerRejectsUnfilledTemplate(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	templateID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetProjectByKey :one`)).WithArgs("BUG").
+		WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(testProjectID, "BUG", "Default project", "", uuid.New(), time.Now(), time.Now(), 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugTemplateByID :one`)).WithArgs(templateID).
+		WillReturnRows(sqlmock.NewRows(bugTemplateColumns).
+			AddRow(templateID, testProjectID, "Crash report", crashTemplate, `{crash}`, `{}`, time.Now(), time.Now()))
+
+	body := bytes.NewBufferString(`{"title":"app crashes","description":"it crashes","template_id":"` + templateID.String() + `"}`)
+	req := httptest.NewRequest("POST", "/api/bugs", body)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", uuid.New()))
+	w := httptest.NewRecorder()
+	cfg.CreateBugHandler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Steps to reproduce, Expected result, Actual result")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateBugHandlerAppliesTemplateLabels(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	templateID := uuid.New()
+	description := "## Steps to reproduce\n1. open settings\n## Expected result\nsettings open\n## Actual result\nthe app closes"
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetProjectByKey :one`)).WithArgs("BUG").
+		WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(testProjectID, "BUG", "Default project", "", uuid.New(), time.Now(), time.Now(), 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugTemplateByID :one`)).WithArgs(templateID).
+		WillReturnRows(sqlmock.NewRows(bugTemplateColumns).
+			AddRow(templateID, testProjectID, "Crash report", crashTemplate, `{crash}`, `{}`, time.Now(), time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: AllocateBugNumber :one`)).
+		WithArgs(testProjectID).WillReturnRows(sqlmock.NewRows([]string{"last_bug_number"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateBug :one`)).
+		WithArgs("app crashes", description, userID, "major", "P2", testProjectID, 1, "BUG-1", nil, nil, nil, false).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "app crashes", description, userID, time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddBugWatcher :exec`)).
+		WithArgs(bugID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddLabelsToBugByName :exec`)).
+		WithArgs(bugID, "{\"crash\"}").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	requestBody, err := json.Marshal(CreateBugRequest{Title: "app crashes", Description: description, TemplateID: &templateID})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/api/bugs", bytes.NewBuffer(requestBody))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	cfg.CreateBugHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateBugTemplateHandlerRejectsUnknownField(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetProjectByKey :one`)).WithArgs("API").
+		WillReturnRows(sqlmock.NewRows(projectColumns).AddRow(testProjectID, "API", "Public API", "", uuid.New(), time.Now(), time.Now(), 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetCustomFieldsByProject :many`)).WithArgs(testProjectID).
+		WillReturnRows(sqlmock.NewRows(customFieldColumns).
+			AddRow(uuid.New(), testProjectID, "environment", "text", `{}`, time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/projects/{key}/templates", cfg.CreateBugTemplateHandler)
+	body := bytes.NewBufferString(`{"name":"Crash report","required_fields":["browser"]}`)
+	req := httptest.NewRequest("POST", "/api/projects/API/templates", body)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "the project has no field browser")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type TrashedBugResponse struct {
+	BugResponse
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+func toTrashedBugResponse(bug database.Bug) TrashedBugResponse {
+	return TrashedBugResponse{
+		BugResponse: toBugResponse(bug),
+		DeletedAt:   bug.DeletedAt.Time,
+	}
+}
+
+// @Summary List deleted bugs
+// @Description admin can list the bugs in the trash, most recently deleted first
+// @Tags bugs
+// @Produce json
+// @Success 200 {array} TrashedBugResponse
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/trash [get]
+// @Security BearerAuth
+func (cfg *APIConfig) GetTrashHandler(w http.ResponseWriter, r *http.Request) {
+	bugs, err := cfg.DB.GetDeletedBugs(r.Context())
+	if err != nil {
+		slog.Error("fetching deleted bugs failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch bugs")
+		return
+	}
+	res := make([]TrashedBugResponse, 0, len(bugs))
+	for _, bug := range bugs {
+		res = append(res, toTrashedBugResponse(bug))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Restore a deleted bug
+// @Description admin can move a bug out of the trash. Deleted bugs are addressed by id because their key no longer resolves
+// @Tags bugs
+// @Produce json
+// @Param bugid path string true "Bug ID" example:"87f0ea02-7b24-41bd-8418-0831a019fc87"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/restore [post]
+// @Security BearerAuth
+func (cfg *APIConfig) RestoreBugHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "RestoreBugHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	bugID, err := uuid.Parse(r.PathValue("bugid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format id")
+		return
+	}
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot restore bug")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	deleted, err := qtx.GetDeletedBugByID(r.Context(), bugID)
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "no deleted bug found with the id")
+		return
+	}
+	if err != nil {
+		logger.Error("database operation failed", "bugID", bugID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot restore bug")
+		return
+	}
+	bug, err := qtx.RestoreBug(r.Context(), bugID)
+	if err != nil {
+		logger.Error("database operation failed", "bugID", bugID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot restore bug")
+		return
+	}
+	err = recordBugChanges(r.Context(), qtx, bugID, userID, []bugChange{
+		{"deleted_at", timeValue(deleted.DeletedAt), sql.NullString{}},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot restore bug")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot restore bug")
+		return
+	}
+	logger.Info("bug restored", "bugID", bugID)
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponse(bug))
+}
+
+// PurgeTrash permanently deletes bugs that have been in the trash for longer
+// than retention and returns how many were removed. Attachment rows go with their
+// bug; the stored files are removed once no other attachment shares their contents.
+func (cfg *APIConfig) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-retention)
+	tx, err := cfg.SQLDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	hashes, err := qtx.GetPurgeableAttachmentHashes(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	purged, err := qtx.PurgeDeletedBugs(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	var orphaned []string
+	if len(hashes) > 0 {
+		orphaned, err = qtx.GetUnreferencedAttachmentHashes(ctx, hashes)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	if len(orphaned) > 0 {
+		if err := cfg.removeAttachmentFiles(ctx, orphaned); err != nil {
+			return purged, err
+		}
+	}
+	return purged, nil
+}
+
+// removeAttachmentFiles deletes the stored files of hashes that no attachment refers
+// to any more. An upload of the same contents may have added a reference since they
+// were found, so each hash is locked the way UploadAttachmentHandler locks it and
+// checked again before its file goes.
+func (cfg *APIConfig) removeAttachmentFiles(ctx context.Context, hashes []string) error {
+	tx, err := cfg.SQLDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	for _, hash := range hashes {
+		if err := qtx.LockAttachmentHash(ctx, hash); err != nil {
+			return err
+		}
+	}
+	unreferenced, err := qtx.GetUnreferencedAttachmentHashes(ctx, hashes)
+	if err != nil {
+		return err
+	}
+	// The rows are gone at this point, so a file that cannot be removed is only logged
+	// and not retried.
+	for _, hash := range unreferenced {
+		if err := cfg.Storage.Delete(ctx, hash); err != nil {
+			slog.Error("removing attachment file failed", "sha256", hash, "error", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// RunTrashPurger calls PurgeTrash every interval until ctx is cancelled.
+func (cfg *APIConfig) RunTrashPurger(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purged, err := cfg.PurgeTrash(ctx, retention)
+		if err != nil {
+			slog.Error("purging deleted bugs failed", "error", err)
+		} else if purged > 0 {
+			slog.Info("purged deleted bugs", "count", purged)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
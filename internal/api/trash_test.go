@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blacktag/bugby-Go/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrashHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	deletedAt := time.Date(2025, 4, 2, 9, 30, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetDeletedBugs :many`)).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, deletedAt, 0, nil, nil, nil, 0, nil, nil, false))
+
+	req := httptest.NewRequest("GET", "/api/bugs/trash", nil)
+	w := httptest.NewRecorder()
+	cfg.GetTrashHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []TrashedBugResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	if assert.Len(t, response, 1) {
+		assert.Equal(t, "BUG-1", response[0].Key)
+		assert.True(t, deletedAt.Equal(response[0].DeletedAt))
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreBugHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	adminID := uuid.New()
+	bugID := uuid.New()
+	deletedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetDeletedBugByID :one`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, deletedAt, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: RestoreBug :one`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectExec(createBugEventQuery).
+		WithArgs(bugID, adminID, "deleted_at", "2026-10-01T12:00:00Z", nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/restore", cfg.RestoreBugHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/restore", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", adminID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BugResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, bugID, response.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreBugHandlerNotInTrash(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetDeletedBugByID :one`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns))
+	mock.ExpectRollback()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/restore", cfg.RestoreBugHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/restore", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", uuid.New()))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeTrash(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetPurgeableAttachmentHashes :many`)).
+		WithArgs(sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"sha256"}))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: PurgeDeletedBugs :execrows`)).
+		WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	purged, err := cfg.PurgeTrash(context.Background(), 30*24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeTrashRemovesUnreferencedFiles(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+	store, err := storage.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create storage: %v", err)
+	}
+	cfg.Storage = store
+	ctx := context.Background()
+	assert.NoError(t, store.Put(ctx, "aaa111", strings.NewReader("only on the purged bug")))
+	assert.NoError(t, store.Put(ctx, "bbb222", strings.NewReader("also on a live bug")))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetPurgeableAttachmentHashes :many`)).
+		WithArgs(sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"sha256"}).AddRow("aaa111").AddRow("bbb222"))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: PurgeDeletedBugs :execrows`)).
+		WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetUnreferencedAttachmentHashes :many`)).
+		WithArgs("{\"aaa111\",\"bbb222\"}").WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow("aaa111"))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(lockAttachmentHashQuery).WithArgs("aaa111").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetUnreferencedAttachmentHashes :many`)).
+		WithArgs("{\"aaa111\"}").WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow("aaa111"))
+	mock.ExpectCommit()
+
+	purged, err := cfg.PurgeTrash(ctx, 30*24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	_, err = store.Open(ctx, "aaa111")
+	assert.True(t, errors.Is(err, storage.ErrNotFound))
+	file, err := store.Open(ctx, "bbb222")
+	if assert.NoError(t, err) {
+		file.Close()
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeTrashKeepsFileUploadedAgain(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+	store, err := storage.NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create storage: %v", err)
+	}
+	cfg.Storage = store
+	ctx := context.Background()
+	assert.NoError(t, store.Put(ctx, "aaa111", strings.NewReader("attached again while purging")))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetPurgeableAttachmentHashes :many`)).
+		WithArgs(sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"sha256"}).AddRow("aaa111"))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: PurgeDeletedBugs :execrows`)).
+		WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetUnreferencedAttachmentHashes :many`)).
+		WithArgs("{\"aaa111\"}").WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow("aaa111"))
+	mock.ExpectCommit()
+	// an upload committed a new reference before the lock was taken
+	mock.ExpectBegin()
+	mock.ExpectExec(lockAttachmentHashQuery).WithArgs("aaa111").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetUnreferencedAttachmentHashes :many`)).
+		WithArgs("{\"aaa111\"}").WillReturnRows(sqlmock.NewRows([]string{"hash"}))
+	mock.ExpectCommit()
+
+	purged, err := cfg.PurgeTrash(ctx, 30*24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	file, err := store.Open(ctx, "aaa111")
+	if assert.NoError(t, err) {
+		file.Close()
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -43,6 +43,10 @@ type UpdateRequest struct {
 	Password string `json:"password" example:"mysecret"`
 }
 
+type SetUserRoleRequest struct {
+	Role string `json:"role" example:"security"`
+}
+
 type UpdateResponse struct {
 	ID        uuid.UUID `json:"id"`
 	Email     string    `json:"email"`
@@ -310,3 +314,58 @@ func (cfg *APIConfig) GetUsersHandler (w http.ResponseWriter, r *http.Request) {
 	logger.Info("completed handler(getall users)")
 
 }
+
+// userRoles are the roles an admin can give a user. rbac_policy.csv lists what each of
+// them may do.
+var userRoles = map[string]bool{"user": true, "admin": true, roleSecurity: true}
+
+// @Summary Change a user's role
+// @Description Admins give users the user, admin or security role. The security role sees every confidential bug.
+// @Tags users
+// @Accept json
+// @Param id path string true "User ID"
+// @Param request body SetUserRoleRequest true "New role"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /users/{id}/role [put]
+// @Security BearerAuth
+func (cfg *APIConfig) SetUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "SetUserRoleHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	var req SetUserRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if !userRoles[req.Role] {
+		utils.RespondWithError(w, http.StatusBadRequest, "role must be user, admin or security")
+		return
+	}
+
+	updated, err := cfg.DB.SetUserRole(r.Context(), database.SetUserRoleParams{
+		ID:   userID,
+		Role: req.Role,
+	})
+	if err != nil {
+		logger.Error("updating user role failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change user role")
+		return
+	}
+	if updated == 0 {
+		utils.RespondWithError(w, http.StatusNotFound, "user not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -114,4 +114,54 @@ RETURNING id, created_at, updated_at, email, hashed_password, role`
 
 
 
-}
\ No newline at end of file
+}
+
+func TestSetUserRoleHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: SetUserRole :execrows`)).
+		WithArgs(userID, roleSecurity).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/users/{id}/role", cfg.SetUserRoleHandler)
+	req := httptest.NewRequest("PUT", "/api/users/"+userID.String()+"/role", bytes.NewBufferString(`{"role":"security"}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetUserRoleHandlerRejectsUnknownRole(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/users/{id}/role", cfg.SetUserRoleHandler)
+	req := httptest.NewRequest("PUT", "/api/users/"+uuid.New().String()+"/role", bytes.NewBufferString(`{"role":"root"}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetUserRoleHandlerUnknownUser(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: SetUserRole :execrows`)).
+		WithArgs(userID, "admin").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/users/{id}/role", cfg.SetUserRoleHandler)
+	req := httptest.NewRequest("PUT", "/api/users/"+userID.String()+"/role", bytes.NewBufferString(`{"role":"admin"}`))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type VoteResponse struct {
+	BugID uuid.UUID `json:"bug_id"`
+	Votes int32     `json:"votes"`
+	Voted bool      `json:"voted"`
+}
+
+// @Summary Vote for a bug
+// @Description Adds the logged in user's vote ("affects me too") to the bug. Voting again has no effect.
+// @Tags votes
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {object} VoteResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/vote [put]
+// @Security BearerAuth
+func (cfg *APIConfig) VoteBugHandler(w http.ResponseWriter, r *http.Request) {
+	cfg.setBugVote(w, r, "VoteBugHandler", true)
+}
+
+// @Summary Withdraw a vote
+// @Description Removes the logged in user's vote from the bug. Withdrawing a vote that was never cast has no effect.
+// @Tags votes
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {object} VoteResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/vote [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) UnvoteBugHandler(w http.ResponseWriter, r *http.Request) {
+	cfg.setBugVote(w, r, "UnvoteBugHandler", false)
+}
+
+// setBugVote casts or withdraws the user's vote and keeps the bug's vote count in
+// step with it in the same transaction.
+func (cfg *APIConfig) setBugVote(w http.ResponseWriter, r *http.Request, handler string, vote bool) {
+	logger := slog.Default().With(
+		"handler", handler,
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	logger = logger.With("userID", userID, "bugID", bug.ID)
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change vote")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	params := database.AddBugVoteParams{BugID: bug.ID, UserID: userID}
+	var changed int64
+	delta := int32(1)
+	if vote {
+		changed, err = qtx.AddBugVote(r.Context(), params)
+	} else {
+		changed, err = qtx.RemoveBugVote(r.Context(), database.RemoveBugVoteParams(params))
+		delta = -1
+	}
+	if err != nil {
+		logger.Error("changing vote failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change vote")
+		return
+	}
+	votes := bug.VoteCount
+	if changed > 0 {
+		votes, err = qtx.AdjustBugVoteCount(r.Context(), database.AdjustBugVoteCountParams{ID: bug.ID, Delta: delta})
+		if err != nil {
+			logger.Error("updating vote count failed", "error", err)
+			utils.RespondWithError(w, http.StatusInternalServerError, "cannot change vote")
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change vote")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, VoteResponse{BugID: bug.ID, Votes: votes, Voted: vote})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoteBugHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 4, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddBugVote :execrows`)).
+		WithArgs(bugID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: AdjustBugVoteCount :one`)).
+		WithArgs(bugID, 1).WillReturnRows(sqlmock.NewRows([]string{"vote_count"}).AddRow(5))
+	mock.ExpectCommit()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/vote", cfg.VoteBugHandler)
+	req := httptest.NewRequest("PUT", "/api/bugs/"+bugID.String()+"/vote", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response VoteResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, int32(5), response.Votes)
+	assert.True(t, response.Voted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnvoteBugHandlerWithoutVote(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 4, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: RemoveBugVote :execrows`)).
+		WithArgs(bugID, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/vote", cfg.UnvoteBugHandler)
+	req := httptest.NewRequest("DELETE", "/api/bugs/"+bugID.String()+"/vote", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response VoteResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	assert.Equal(t, int32(4), response.Votes)
+	assert.False(t, response.Voted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugsHandlerSortsByVotes(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: ListBugs :many`)).
+		WithArgs(nil, nil, nil, nil, nil, nil, nil, false, nil, "votes").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(uuid.New(), "checkout broken", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 2, "BUG-2", nil, nil, nil, 12, nil, nil, nil, 0, nil, nil, false).
+			AddRow(uuid.New(), "typo in footer", "", uuid.New(), time.Now(), time.Now(), "open", "trivial", "P4", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 1, nil, nil, nil, 0, nil, nil, false))
+
+	req := httptest.NewRequest("GET", "/api/bugs?sort=votes", nil)
+	w := httptest.NewRecorder()
+	cfg.GetBugsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []BugResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	if assert.Len(t, response, 2) {
+		assert.Equal(t, int32(12), response[0].Votes)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+type WatcherResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Email    string    `json:"email,omitempty" example:"dev@example.com"`
+	Watching time.Time `json:"watching_since"`
+}
+
+// @Summary List watchers of a bug
+// @Description Returns everyone who is notified about changes to the bug. Authors and assignees watch their bugs automatically. Emails are only shown to logged in users.
+// @Tags watchers
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {array} WatcherResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/watchers [get]
+func (cfg *APIConfig) GetBugWatchersHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "GetBugWatchersHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	cfg.respondWithBugWatchers(w, r, logger, bug.ID, http.StatusOK)
+}
+
+// @Summary Watch a bug
+// @Description Subscribes the logged in user to changes of the bug
+// @Tags watchers
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {array} WatcherResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/watchers/me [put]
+// @Security BearerAuth
+func (cfg *APIConfig) WatchBugHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "WatchBugHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	err := cfg.DB.AddBugWatcher(r.Context(), database.AddBugWatcherParams{
+		BugID:  bug.ID,
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Error("adding watcher failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot watch bug")
+		return
+	}
+	cfg.respondWithBugWatchers(w, r, logger, bug.ID, http.StatusOK)
+}
+
+// @Summary Stop watching a bug
+// @Description Unsubscribes the logged in user from changes of the bug
+// @Tags watchers
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {array} WatcherResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/watchers/me [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) UnwatchBugHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UnwatchBugHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	removed, err := cfg.DB.RemoveBugWatcher(r.Context(), database.RemoveBugWatcherParams{
+		BugID:  bug.ID,
+		UserID: userID,
+	})
+	if err != nil {
+		logger.Error("removing watcher failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot unwatch bug")
+		return
+	}
+	if removed == 0 {
+		utils.RespondWithError(w, http.StatusNotFound, "you are not watching this bug")
+		return
+	}
+	cfg.respondWithBugWatchers(w, r, logger, bug.ID, http.StatusOK)
+}
+
+func (cfg *APIConfig) respondWithBugWatchers(w http.ResponseWriter, r *http.Request, logger *slog.Logger, bugID uuid.UUID, status int) {
+	watchers, err := cfg.DB.GetBugWatchers(r.Context(), bugID)
+	if err != nil {
+		logger.Error("fetching watchers failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch watchers")
+		return
+	}
+	// anonymous viewers of public bugs must not harvest addresses
+	_, loggedIn := r.Context().Value("userID").(uuid.UUID)
+	res := make([]WatcherResponse, 0, len(watchers))
+	for _, watcher := range watchers {
+		watcherRes := WatcherResponse{
+			UserID:   watcher.ID,
+			Watching: watcher.CreatedAt,
+		}
+		if loggedIn {
+			watcherRes.Email = watcher.Email
+		}
+		res = append(res, watcherRes)
+	}
+	utils.RespondWithJSON(w, status, res)
+}
+
+// @Summary List bugs I watch
+// @Description Returns the bugs the logged in user watches, most recently updated first
+// @Tags users
+// @Produce json
+// @Success 200 {array} BugResponse
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /users/me/watching [get]
+// @Security BearerAuth
+func (cfg *APIConfig) GetMyWatchedBugsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+	bugs, err := cfg.DB.GetWatchedBugs(r.Context(), database.GetWatchedBugsParams{
+		UserID:          userID,
+		SeeConfidential: seesConfidentialBugs(role),
+	})
+	if err != nil {
+		slog.Error("fetching watched bugs failed", "userID", userID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch bugs")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponses(bugs))
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchBugHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: AddBugWatcher :exec`)).
+		WithArgs(bugID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugWatchers :many`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "created_at"}).
+			AddRow(userID, "dev@example.com", time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/watchers/me", cfg.WatchBugHandler)
+	req := httptest.NewRequest("PUT", "/api/bugs/"+bugID.String()+"/watchers/me", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response []WatcherResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.Len(t, response, 1) {
+		assert.Equal(t, userID, response[0].UserID)
+		assert.Equal(t, "dev@example.com", response[0].Email)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnwatchBugHandlerNotWatching(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: RemoveBugWatcher :execrows`)).
+		WithArgs(bugID, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/watchers/me", cfg.UnwatchBugHandler)
+	req := httptest.NewRequest("DELETE", "/api/bugs/"+bugID.String()+"/watchers/me", nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBugWatchersHandlerHidesEmailsFromAnonymous(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	watcherID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugWatchers :many`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "created_at"}).
+			AddRow(watcherID, "dev@example.com", time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/watchers", cfg.GetBugWatchersHandler)
+	req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String()+"/watchers", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	assert.NotContains(t, w.Body.String(), "dev@example.com")
+	var response []WatcherResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if assert.Len(t, response, 1) {
+		assert.Equal(t, watcherID, response[0].UserID)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,578 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blacktag/bugby-Go/internal/database"
+	"github.com/blacktag/bugby-Go/internal/utils"
+	"github.com/google/uuid"
+)
+
+// maxWorkLogMinutes caps a single entry at one day of work.
+const maxWorkLogMinutes = 24 * 60
+
+var errInvalidDuration = errors.New("duration must be a positive number of minutes like 45m or 1h30m")
+
+type CreateWorkLogRequest struct {
+	Duration string `json:"duration" example:"1h30m"`
+	Note     string `json:"note" example:"reproduced on staging"`
+	// Date is the day the work was done and defaults to today.
+	Date string `json:"date" example:"2025-06-02"`
+}
+
+type UpdateWorkLogRequest struct {
+	Duration *string `json:"duration" example:"2h"`
+	Note     *string `json:"note" example:"reproduced and bisected"`
+	Date     *string `json:"date" example:"2025-06-02"`
+}
+
+type SetBugEstimateRequest struct {
+	Estimate string `json:"estimate" example:"8h"`
+}
+
+type WorkLogResponse struct {
+	ID        uuid.UUID `json:"id"`
+	BugID     uuid.UUID `json:"bug_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Minutes   int32     `json:"minutes" example:"90"`
+	Duration  string    `json:"duration" example:"1h30m"`
+	Note      string    `json:"note"`
+	Date      string    `json:"date" example:"2025-06-02"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WorkLogUserTotal struct {
+	UserID       uuid.UUID `json:"user_id"`
+	TotalMinutes int64     `json:"total_minutes" example:"300"`
+}
+
+// BugWorkLogsResponse lists the time logged on a bug. RemainingMinutes is the
+// estimate minus the time logged so far and goes negative once the estimate is
+// exceeded.
+type BugWorkLogsResponse struct {
+	BugID            uuid.UUID          `json:"bug_id"`
+	EstimateMinutes  *int32             `json:"estimate_minutes" example:"480"`
+	TotalMinutes     int64              `json:"total_minutes" example:"300"`
+	RemainingMinutes *int64             `json:"remaining_minutes" example:"180"`
+	ByUser           []WorkLogUserTotal `json:"by_user"`
+	Entries          []WorkLogResponse  `json:"entries"`
+}
+
+type WorkLogReportRow struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Email   string    `json:"email"`
+	Week    string    `json:"week" example:"2025-06-02"`
+	Minutes int64     `json:"minutes" example:"600"`
+}
+
+type WorkLogReportResponse struct {
+	From         string             `json:"from" example:"2025-06-01"`
+	To           string             `json:"to" example:"2025-06-30"`
+	TotalMinutes int64              `json:"total_minutes" example:"2400"`
+	ByUser       []WorkLogUserTotal `json:"by_user"`
+	Weeks        []WorkLogReportRow `json:"weeks"`
+}
+
+func toWorkLogResponse(log database.WorkLog) WorkLogResponse {
+	return WorkLogResponse{
+		ID:        log.ID,
+		BugID:     log.BugID,
+		UserID:    log.UserID,
+		Minutes:   log.Minutes,
+		Duration:  formatMinutes(int64(log.Minutes)),
+		Note:      log.Note,
+		Date:      log.WorkDate.Format(dateLayout),
+		CreatedAt: log.CreatedAt,
+		UpdatedAt: log.UpdatedAt,
+	}
+}
+
+// parseMinutes reads a duration such as 45m, 2h or 1h30m as whole minutes.
+func parseMinutes(s string) (int32, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil || d <= 0 || d%time.Minute != 0 {
+		return 0, errInvalidDuration
+	}
+	return int32(d / time.Minute), nil
+}
+
+// formatMinutes is the inverse of parseMinutes, e.g. 90 becomes 1h30m.
+func formatMinutes(minutes int64) string {
+	sign := ""
+	if minutes < 0 {
+		sign, minutes = "-", -minutes
+	}
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%s%dm", sign, m)
+	case m == 0:
+		return fmt.Sprintf("%s%dh", sign, h)
+	}
+	return fmt.Sprintf("%s%dh%dm", sign, h, m)
+}
+
+// totalsByUser adds up minutes per user, keeping users in order of first appearance.
+func totalsByUser(logs []database.WorkLog) (int64, []WorkLogUserTotal) {
+	var total int64
+	byUser := []WorkLogUserTotal{}
+	index := make(map[uuid.UUID]int)
+	for _, log := range logs {
+		total += int64(log.Minutes)
+		i, ok := index[log.UserID]
+		if !ok {
+			i = len(byUser)
+			index[log.UserID] = i
+			byUser = append(byUser, WorkLogUserTotal{UserID: log.UserID})
+		}
+		byUser[i].TotalMinutes += int64(log.Minutes)
+	}
+	return total, byUser
+}
+
+// @Summary Log time on a bug
+// @Description Records time the logged in user spent on the bug. A single entry covers at most one day.
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param request body CreateWorkLogRequest true "time spent"
+// @Success 201 {object} WorkLogResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/worklogs [post]
+// @Security BearerAuth
+func (cfg *APIConfig) CreateWorkLogHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "CreateWorkLogHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	var req CreateWorkLogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	minutes, err := parseMinutes(req.Duration)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if minutes > maxWorkLogMinutes {
+		utils.RespondWithError(w, http.StatusBadRequest, "a work log covers at most 24h, log each day separately")
+		return
+	}
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.Date != "" {
+		date, err = time.Parse(dateLayout, req.Date)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "date must look like 2025-06-02")
+			return
+		}
+	}
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+
+	workLog, err := cfg.DB.CreateWorkLog(r.Context(), database.CreateWorkLogParams{
+		BugID:    bug.ID,
+		UserID:   userID,
+		Minutes:  minutes,
+		Note:     req.Note,
+		WorkDate: date,
+	})
+	if err != nil {
+		logger.Error("database operation failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot log time")
+		return
+	}
+	logger.Info("time logged", "bugID", bug.ID, "userID", userID, "minutes", minutes)
+	utils.RespondWithJSON(w, http.StatusCreated, toWorkLogResponse(workLog))
+}
+
+// @Summary List time logged on a bug
+// @Description Returns the work logs of a bug, newest day first, with the total per user and for the bug against its estimate
+// @Tags worklogs
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {object} BugWorkLogsResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/worklogs [get]
+func (cfg *APIConfig) GetWorkLogsHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "GetWorkLogsHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	logs, err := cfg.DB.GetWorkLogsByBug(r.Context(), bug.ID)
+	if err != nil {
+		logger.Error("fetching work logs failed", "bugID", bug.ID, "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt fetch work logs")
+		return
+	}
+	res := BugWorkLogsResponse{
+		BugID:   bug.ID,
+		Entries: make([]WorkLogResponse, 0, len(logs)),
+	}
+	res.TotalMinutes, res.ByUser = totalsByUser(logs)
+	if bug.EstimateMinutes.Valid {
+		remaining := int64(bug.EstimateMinutes.Int32) - res.TotalMinutes
+		res.EstimateMinutes = &bug.EstimateMinutes.Int32
+		res.RemainingMinutes = &remaining
+	}
+	for _, log := range logs {
+		res.Entries = append(res.Entries, toWorkLogResponse(log))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
+
+// @Summary Update a work log
+// @Description The user who logged the time or an admin can correct its duration, note or date
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param worklogid path string true "Work log ID" example:"3f1c2b7e-0a53-4c8e-9d7a-0c0f4c8a8b11"
+// @Param request body UpdateWorkLogRequest true "work log updation data"
+// @Success 200 {object} WorkLogResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/worklogs/{worklogid} [put]
+// @Security BearerAuth
+func (cfg *APIConfig) UpdateWorkLogHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "UpdateWorkLogHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	workLog, ok := cfg.workLogOwnedByUser(w, r, logger)
+	if !ok {
+		return
+	}
+	var req UpdateWorkLogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("failed to decode json", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	params := database.UpdateWorkLogParams{
+		ID:   workLog.ID,
+		Note: toNullString(req.Note),
+	}
+	if req.Duration != nil {
+		minutes, err := parseMinutes(*req.Duration)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if minutes > maxWorkLogMinutes {
+			utils.RespondWithError(w, http.StatusBadRequest, "a work log covers at most 24h, log each day separately")
+			return
+		}
+		params.Minutes = sql.NullInt32{Int32: minutes, Valid: true}
+	}
+	if req.Date != nil {
+		date, err := time.Parse(dateLayout, *req.Date)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "date must look like 2025-06-02")
+			return
+		}
+		params.WorkDate = sql.NullTime{Time: date, Valid: true}
+	}
+
+	updated, err := cfg.DB.UpdateWorkLog(r.Context(), params)
+	if err != nil {
+		logger.Error("updating work log failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot update work log")
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, toWorkLogResponse(updated))
+}
+
+// @Summary Delete a work log
+// @Description The user who logged the time or an admin can remove the entry
+// @Tags worklogs
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param worklogid path string true "Work log ID" example:"3f1c2b7e-0a53-4c8e-9d7a-0c0f4c8a8b11"
+// @Success 204 {string} string "No content"
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/worklogs/{worklogid} [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) DeleteWorkLogHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "DeleteWorkLogHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	workLog, ok := cfg.workLogOwnedByUser(w, r, logger)
+	if !ok {
+		return
+	}
+	if _, err := cfg.DB.DeleteWorkLog(r.Context(), workLog.ID); err != nil {
+		logger.Error("deleting work log failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot delete work log")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// workLogOwnedByUser loads the work log addressed by the path and checks that the
+// logged in user wrote it or is an admin. It writes the error response itself when
+// it returns false.
+func (cfg *APIConfig) workLogOwnedByUser(w http.ResponseWriter, r *http.Request, logger *slog.Logger) (database.WorkLog, bool) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		logger.Error("user id missing in context")
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return database.WorkLog{}, false
+	}
+	role, _ := r.Context().Value("role").(string)
+	workLogID, err := uuid.Parse(r.PathValue("worklogid"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "wrong format work log Id")
+		return database.WorkLog{}, false
+	}
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return database.WorkLog{}, false
+	}
+
+	workLog, err := cfg.DB.GetWorkLogByID(r.Context(), workLogID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && workLog.BugID != bug.ID) {
+		utils.RespondWithError(w, http.StatusNotFound, "no work log found with the id")
+		return database.WorkLog{}, false
+	}
+	if err != nil {
+		logger.Error("fetching work log failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot fetch work log")
+		return database.WorkLog{}, false
+	}
+	if workLog.UserID != userID && role != "admin" {
+		logger.Error("user did not log this time", "userID", userID, "workLogID", workLogID)
+		utils.RespondWithError(w, http.StatusForbidden, "only the user who logged the time or admin can change it")
+		return database.WorkLog{}, false
+	}
+	return workLog, true
+}
+
+// @Summary Set the original estimate of a bug
+// @Description The author, the assignee or an admin can set how long fixing the bug should take
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Param request body SetBugEstimateRequest true "estimate"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/estimate [put]
+// @Security BearerAuth
+func (cfg *APIConfig) SetBugEstimateHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "SetBugEstimateHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	var req SetBugEstimateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("given request body in wrong format", "error", err)
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	minutes, err := parseMinutes(req.Estimate)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "estimate must be a positive number of minutes like 45m or 8h")
+		return
+	}
+	cfg.setBugEstimate(w, r, logger, sql.NullInt32{Int32: minutes, Valid: true})
+}
+
+// @Summary Clear the original estimate of a bug
+// @Description The author, the assignee or an admin can remove the bug's estimate
+// @Tags worklogs
+// @Produce json
+// @Param bugid path string true "Bug ID or key" example:"API-123"
+// @Success 200 {object} BugResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 403 {object} utils.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} utils.ErrorResponse "Not Found - Resource doesn't exist"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /bugs/{bugid}/estimate [delete]
+// @Security BearerAuth
+func (cfg *APIConfig) ClearBugEstimateHandler(w http.ResponseWriter, r *http.Request) {
+	logger := slog.Default().With(
+		"handler", "ClearBugEstimateHandler",
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+	cfg.setBugEstimate(w, r, logger, sql.NullInt32{})
+}
+
+func (cfg *APIConfig) setBugEstimate(w http.ResponseWriter, r *http.Request, logger *slog.Logger, estimate sql.NullInt32) {
+	userID, ok := r.Context().Value("userID").(uuid.UUID)
+	if !ok {
+		utils.RespondWithError(w, http.StatusUnauthorized, "invalid or missing user ID")
+		return
+	}
+	role, _ := r.Context().Value("role").(string)
+
+	bug, ok := cfg.bugFromRef(w, r, logger, r.PathValue("bugid"))
+	if !ok {
+		return
+	}
+	if !canManageBug(bug, userID, role) {
+		utils.RespondWithError(w, http.StatusForbidden, "only author, assignee or admin can change the estimate")
+		return
+	}
+
+	tx, err := cfg.SQLDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		logger.Error("cannot start transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change estimate")
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.DB.WithTx(tx)
+
+	err = qtx.SetBugEstimate(r.Context(), database.SetBugEstimateParams{
+		ID:              bug.ID,
+		EstimateMinutes: estimate,
+	})
+	if err != nil {
+		logger.Error("updating estimate failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change estimate")
+		return
+	}
+	err = recordBugChanges(r.Context(), qtx, bug.ID, userID, []bugChange{
+		{"estimate_minutes", minutesValue(bug.EstimateMinutes), minutesValue(estimate)},
+	})
+	if err != nil {
+		logger.Error("recording bug history failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change estimate")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Error("cannot commit transaction", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "cannot change estimate")
+		return
+	}
+	bug.EstimateMinutes = estimate
+	utils.RespondWithJSON(w, http.StatusOK, toBugResponse(bug))
+}
+
+func minutesValue(minutes sql.NullInt32) sql.NullString {
+	if !minutes.Valid {
+		return sql.NullString{}
+	}
+	return textValue(strconv.Itoa(int(minutes.Int32)))
+}
+
+// @Summary Time report
+// @Description Sums the time logged between two dates, inclusive, by user and by week. Weeks start on Monday.
+// @Tags worklogs
+// @Produce json
+// @Param from query string true "First day" example:"2025-06-01"
+// @Param to query string true "Last day" example:"2025-06-30"
+// @Param project query string false "Only time logged on bugs of the project with this key"
+// @Success 200 {object} WorkLogReportResponse
+// @Failure 400 {object} utils.ErrorResponse "Bad Request - Invalid input"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized - Missing/invalid credentials"
+// @Failure 500 {object} utils.ErrorResponse "Internal Server Error"
+// @Router /reports/worklogs [get]
+// @Security BearerAuth
+func (cfg *APIConfig) GetWorkLogReportHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from, err := time.Parse(dateLayout, query.Get("from"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "from must be a date like 2025-06-01")
+		return
+	}
+	to, err := time.Parse(dateLayout, query.Get("to"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "to must be a date like 2025-06-30")
+		return
+	}
+	if to.Before(from) {
+		utils.RespondWithError(w, http.StatusBadRequest, "to must not be before from")
+		return
+	}
+	projectID := uuid.NullUUID{}
+	if key := query.Get("project"); key != "" {
+		project, err := cfg.DB.GetProjectByKey(r.Context(), key)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "unknown project: "+key)
+			return
+		}
+		projectID = uuid.NullUUID{UUID: project.ID, Valid: true}
+	}
+
+	rows, err := cfg.DB.GetWorkLogReport(r.Context(), database.GetWorkLogReportParams{
+		FromDate:  from,
+		ToDate:    to,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		slog.Error("building work log report failed", "error", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "couldnt build report")
+		return
+	}
+	res := WorkLogReportResponse{
+		From:   from.Format(dateLayout),
+		To:     to.Format(dateLayout),
+		ByUser: []WorkLogUserTotal{},
+		Weeks:  make([]WorkLogReportRow, 0, len(rows)),
+	}
+	index := make(map[uuid.UUID]int)
+	for _, row := range rows {
+		res.TotalMinutes += row.Minutes
+		i, ok := index[row.UserID]
+		if !ok {
+			i = len(res.ByUser)
+			index[row.UserID] = i
+			res.ByUser = append(res.ByUser, WorkLogUserTotal{UserID: row.UserID})
+		}
+		res.ByUser[i].TotalMinutes += row.Minutes
+		res.Weeks = append(res.Weeks, WorkLogReportRow{
+			UserID:  row.UserID,
+			Email:   row.Email,
+			Week:    row.Week.Format(dateLayout),
+			Minutes: row.Minutes,
+		})
+	}
+	utils.RespondWithJSON(w, http.StatusOK, res)
+}
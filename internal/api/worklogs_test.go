@@ -0,0 +1,234 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var workLogColumns = []string{"id", "bug_id", "user_id", "minutes", "note", "work_date", "created_at", "updated_at"}
+
+func TestParseMinutes(t *testing.T) {
+	minutes, err := parseMinutes("1h30m")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(90), minutes)
+	assert.Equal(t, "1h30m", formatMinutes(90))
+	assert.Equal(t, "8h", formatMinutes(480))
+	assert.Equal(t, "-45m", formatMinutes(-45))
+
+	for _, bad := range []string{"", "90", "-1h", "30s", "1h30m15s"} {
+		_, err := parseMinutes(bad)
+		assert.ErrorIs(t, err, errInvalidDuration, bad)
+	}
+}
+
+func TestCreateWorkLogHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	workLogID := uuid.New()
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "slow export", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: CreateWorkLog :one`)).
+		WithArgs(bugID, userID, 90, "profiled the export query", date).
+		WillReturnRows(sqlmock.NewRows(workLogColumns).
+			AddRow(workLogID, bugID, userID, 90, "profiled the export query", date, time.Now(), time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/worklogs", cfg.CreateWorkLogHandler)
+	body := `{"duration":"1h30m","note":"profiled the export query","date":"2025-06-02"}`
+	req := httptest.NewRequest("POST", "/api/bugs/"+bugID.String()+"/worklogs", bytes.NewBufferString(body))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response WorkLogResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, workLogID, response.ID)
+	assert.Equal(t, int32(90), response.Minutes)
+	assert.Equal(t, "1h30m", response.Duration)
+	assert.Equal(t, "2025-06-02", response.Date)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateWorkLogHandlerRejectsMoreThanADay(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/worklogs", cfg.CreateWorkLogHandler)
+	req := httptest.NewRequest("POST", "/api/bugs/"+uuid.NewString()+"/worklogs", bytes.NewBufferString(`{"duration":"25h"}`))
+	req = req.WithContext(context.WithValue(req.Context(), "userID", uuid.New()))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWorkLogsHandlerTotals(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "slow export", "", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, 240, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetWorkLogsByBug :many`)).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(workLogColumns).
+			AddRow(uuid.New(), bugID, alice, 120, "", time.Now(), time.Now(), time.Now()).
+			AddRow(uuid.New(), bugID, bob, 60, "", time.Now(), time.Now(), time.Now()).
+			AddRow(uuid.New(), bugID, alice, 90, "", time.Now(), time.Now(), time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/worklogs", cfg.GetWorkLogsHandler)
+	req := httptest.NewRequest("GET", "/api/bugs/"+bugID.String()+"/worklogs", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response BugWorkLogsResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, int64(270), response.TotalMinutes)
+	if assert.NotNil(t, response.RemainingMinutes) {
+		assert.Equal(t, int64(-30), *response.RemainingMinutes)
+	}
+	assert.Equal(t, []WorkLogUserTotal{{UserID: alice, TotalMinutes: 210}, {UserID: bob, TotalMinutes: 60}}, response.ByUser)
+	assert.Len(t, response.Entries, 3)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteWorkLogHandlerOnlyAuthor(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	bugID := uuid.New()
+	workLogID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetWorkLogByID :one`)).WithArgs(workLogID).
+		WillReturnRows(sqlmock.NewRows(workLogColumns).
+			AddRow(workLogID, bugID, uuid.New(), 30, "", time.Now(), time.Now(), time.Now()))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/worklogs/{worklogid}", cfg.DeleteWorkLogHandler)
+	req := httptest.NewRequest("DELETE", "/api/bugs/"+bugID.String()+"/worklogs/"+workLogID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", uuid.New()))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteWorkLogHandlerByBugKey(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	workLogID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetBugByKey :one`)).WithArgs("API-7").
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 7, "API-7", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, false))
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetWorkLogByID :one`)).WithArgs(workLogID).
+		WillReturnRows(sqlmock.NewRows(workLogColumns).
+			AddRow(workLogID, bugID, userID, 30, "", time.Now(), time.Now(), time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta(`-- name: DeleteWorkLog :execrows`)).WithArgs(workLogID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/worklogs/{worklogid}", cfg.DeleteWorkLogHandler)
+	req := httptest.NewRequest("DELETE", "/api/bugs/API-7/worklogs/"+workLogID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteWorkLogHandlerHiddenBug(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	userID := uuid.New()
+	bugID := uuid.New()
+	workLogID := uuid.New()
+	mock.ExpectQuery(getBugByIDQuery).WithArgs(bugID).
+		WillReturnRows(sqlmock.NewRows(bugColumns).
+			AddRow(bugID, "test bug", "test description", uuid.New(), time.Now(), time.Now(), "open", "major", "P2", nil, testProjectID, 1, "BUG-1", nil, nil, nil, 0, nil, nil, nil, 0, nil, nil, true))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/bugs/{bugid}/worklogs/{worklogid}", cfg.DeleteWorkLogHandler)
+	req := httptest.NewRequest("DELETE", "/api/bugs/"+bugID.String()+"/worklogs/"+workLogID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWorkLogReportHandler(t *testing.T) {
+	cfg, mock := setupTest(t)
+	defer cfg.SQLDB.Close()
+
+	alice := uuid.New()
+	bob := uuid.New()
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC)
+	week1 := time.Date(2025, 5, 26, 0, 0, 0, 0, time.UTC)
+	week2 := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`-- name: GetWorkLogReport :many`)).
+		WithArgs(from, to, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "email", "week", "minutes"}).
+			AddRow(alice, "alice@example.com", week1, 60).
+			AddRow(alice, "alice@example.com", week2, 600).
+			AddRow(bob, "bob@example.com", week2, 300))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/reports/worklogs", cfg.GetWorkLogReportHandler)
+	req := httptest.NewRequest("GET", "/api/reports/worklogs?from=2025-06-01&to=2025-06-14", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got: %d. Body: %s", w.Code, w.Body.String())
+	}
+	var response WorkLogReportResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	assert.Equal(t, int64(960), response.TotalMinutes)
+	assert.Equal(t, []WorkLogUserTotal{{UserID: alice, TotalMinutes: 660}, {UserID: bob, TotalMinutes: 300}}, response.ByUser)
+	if assert.Len(t, response.Weeks, 3) {
+		assert.Equal(t, "2025-05-26", response.Weeks[0].Week)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
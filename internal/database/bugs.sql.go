@@ -8,32 +8,64 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createBug = `-- name: CreateBug :one
-INSERT INTO bugs (id, title, description, posted_by, created_at, updated_at)
+INSERT INTO bugs (id, title, description, posted_by, severity, priority, project_id, number, key, assignee_id, component_id, due_at, confidential, created_at, updated_at)
 VALUES (
     gen_random_uuid(),
     $1,
     $2,
     $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
     NOW(),
     NOW()
-    
 )
-RETURNING id, title, description, posted_by, created_at, updated_at
+RETURNING id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential
 `
 
 type CreateBugParams struct {
-	Title       string
-	Description string
-	PostedBy    uuid.UUID
+	Title        string
+	Description  string
+	PostedBy     uuid.UUID
+	Severity     string
+	Priority     string
+	ProjectID    uuid.UUID
+	Number       int32
+	Key          string
+	AssigneeID   uuid.NullUUID
+	ComponentID  uuid.NullUUID
+	DueAt        sql.NullTime
+	Confidential bool
 }
 
 func (q *Queries) CreateBug(ctx context.Context, arg CreateBugParams) (Bug, error) {
-	row := q.db.QueryRowContext(ctx, createBug, arg.Title, arg.Description, arg.PostedBy)
+	row := q.db.QueryRowContext(ctx, createBug,
+		arg.Title,
+		arg.Description,
+		arg.PostedBy,
+		arg.Severity,
+		arg.Priority,
+		arg.ProjectID,
+		arg.Number,
+		arg.Key,
+		arg.AssigneeID,
+		arg.ComponentID,
+		arg.DueAt,
+		arg.Confidential,
+	)
 	var i Bug
 	err := row.Scan(
 		&i.ID,
@@ -42,27 +74,127 @@ func (q *Queries) CreateBug(ctx context.Context, arg CreateBugParams) (Bug, erro
 		&i.PostedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.Severity,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ProjectID,
+		&i.Number,
+		&i.Key,
+		&i.MilestoneID,
+		&i.ComponentID,
+		&i.DeletedAt,
+		&i.VoteCount,
+		&i.DueAt,
+		&i.EstimateMinutes,
+		&i.Resolution,
+		&i.ReopenCount,
+		&i.LastReopenedAt,
+		&i.MergedIntoID,
+		&i.Confidential,
 	)
 	return i, err
 }
 
-const deleteBugByID = `-- name: DeleteBugByID :exec
-DELETE FROM bugs
-WHERE id = $1
+const getAllBugs = `-- name: GetAllBugs :many
+SELECT id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential FROM bugs
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
 `
 
-func (q *Queries) DeleteBugByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteBugByID, id)
-	return err
+func (q *Queries) GetAllBugs(ctx context.Context) ([]Bug, error) {
+	rows, err := q.db.QueryContext(ctx, getAllBugs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Bug
+	for rows.Next() {
+		var i Bug
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.PostedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.Severity,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ProjectID,
+			&i.Number,
+			&i.Key,
+			&i.MilestoneID,
+			&i.ComponentID,
+			&i.DeletedAt,
+			&i.VoteCount,
+			&i.DueAt,
+			&i.EstimateMinutes,
+			&i.Resolution,
+			&i.ReopenCount,
+			&i.LastReopenedAt,
+			&i.MergedIntoID,
+			&i.Confidential,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getAllBugs = `-- name: GetAllBugs :many
-SELECT id, title, description, posted_by, created_at, updated_at FROM bugs
+const getBugByKey = `-- name: GetBugByKey :one
+SELECT id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential FROM bugs
+WHERE key = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetBugByKey(ctx context.Context, key string) (Bug, error) {
+	row := q.db.QueryRowContext(ctx, getBugByKey, key)
+	var i Bug
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.PostedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.Severity,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ProjectID,
+		&i.Number,
+		&i.Key,
+		&i.MilestoneID,
+		&i.ComponentID,
+		&i.DeletedAt,
+		&i.VoteCount,
+		&i.DueAt,
+		&i.EstimateMinutes,
+		&i.Resolution,
+		&i.ReopenCount,
+		&i.LastReopenedAt,
+		&i.MergedIntoID,
+		&i.Confidential,
+	)
+	return i, err
+}
+
+const getBugsByAssignee = `-- name: GetBugsByAssignee :many
+SELECT id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential FROM bugs
+WHERE assignee_id = $1::uuid AND deleted_at IS NULL
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetAllBugs(ctx context.Context) ([]Bug, error) {
-	rows, err := q.db.QueryContext(ctx, getAllBugs)
+func (q *Queries) GetBugsByAssignee(ctx context.Context, assigneeID uuid.UUID) ([]Bug, error) {
+	rows, err := q.db.QueryContext(ctx, getBugsByAssignee, assigneeID)
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +209,24 @@ func (q *Queries) GetAllBugs(ctx context.Context) ([]Bug, error) {
 			&i.PostedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.Severity,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ProjectID,
+			&i.Number,
+			&i.Key,
+			&i.MilestoneID,
+			&i.ComponentID,
+			&i.DeletedAt,
+			&i.VoteCount,
+			&i.DueAt,
+			&i.EstimateMinutes,
+			&i.Resolution,
+			&i.ReopenCount,
+			&i.LastReopenedAt,
+			&i.MergedIntoID,
+			&i.Confidential,
 		); err != nil {
 			return nil, err
 		}
@@ -92,8 +242,8 @@ func (q *Queries) GetAllBugs(ctx context.Context) ([]Bug, error) {
 }
 
 const getBugsByID = `-- name: GetBugsByID :one
-SELECT id, title, description, posted_by, created_at, updated_at FROM bugs
-WHERE Id = $1
+SELECT id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential FROM bugs
+WHERE Id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetBugsByID(ctx context.Context, id uuid.UUID) (Bug, error) {
@@ -106,15 +256,528 @@ func (q *Queries) GetBugsByID(ctx context.Context, id uuid.UUID) (Bug, error) {
 		&i.PostedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.Severity,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ProjectID,
+		&i.Number,
+		&i.Key,
+		&i.MilestoneID,
+		&i.ComponentID,
+		&i.DeletedAt,
+		&i.VoteCount,
+		&i.DueAt,
+		&i.EstimateMinutes,
+		&i.Resolution,
+		&i.ReopenCount,
+		&i.LastReopenedAt,
+		&i.MergedIntoID,
+		&i.Confidential,
+	)
+	return i, err
+}
+
+const getDeletedBugByID = `-- name: GetDeletedBugByID :one
+SELECT id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential FROM bugs
+WHERE id = $1 AND deleted_at IS NOT NULL
+FOR UPDATE
+`
+
+func (q *Queries) GetDeletedBugByID(ctx context.Context, id uuid.UUID) (Bug, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedBugByID, id)
+	var i Bug
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.PostedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.Severity,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ProjectID,
+		&i.Number,
+		&i.Key,
+		&i.MilestoneID,
+		&i.ComponentID,
+		&i.DeletedAt,
+		&i.VoteCount,
+		&i.DueAt,
+		&i.EstimateMinutes,
+		&i.Resolution,
+		&i.ReopenCount,
+		&i.LastReopenedAt,
+		&i.MergedIntoID,
+		&i.Confidential,
 	)
 	return i, err
 }
 
+const getDeletedBugs = `-- name: GetDeletedBugs :many
+SELECT id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential FROM bugs
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+`
+
+func (q *Queries) GetDeletedBugs(ctx context.Context) ([]Bug, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedBugs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Bug
+	for rows.Next() {
+		var i Bug
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.PostedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.Severity,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ProjectID,
+			&i.Number,
+			&i.Key,
+			&i.MilestoneID,
+			&i.ComponentID,
+			&i.DeletedAt,
+			&i.VoteCount,
+			&i.DueAt,
+			&i.EstimateMinutes,
+			&i.Resolution,
+			&i.ReopenCount,
+			&i.LastReopenedAt,
+			&i.MergedIntoID,
+			&i.Confidential,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMostReopenedBugs = `-- name: GetMostReopenedBugs :many
+SELECT id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential FROM bugs
+WHERE deleted_at IS NULL
+  AND reopen_count > 0
+  AND ($1::uuid IS NULL OR project_id = $1)
+  AND (NOT confidential OR $2::boolean OR posted_by = $3 OR assignee_id = $3)
+ORDER BY reopen_count DESC, last_reopened_at DESC
+LIMIT $4
+`
+
+type GetMostReopenedBugsParams struct {
+	ProjectID       uuid.NullUUID
+	SeeConfidential bool
+	ViewerID        uuid.NullUUID
+	MaxResults      int32
+}
+
+func (q *Queries) GetMostReopenedBugs(ctx context.Context, arg GetMostReopenedBugsParams) ([]Bug, error) {
+	rows, err := q.db.QueryContext(ctx, getMostReopenedBugs,
+		arg.ProjectID,
+		arg.SeeConfidential,
+		arg.ViewerID,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Bug
+	for rows.Next() {
+		var i Bug
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.PostedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.Severity,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ProjectID,
+			&i.Number,
+			&i.Key,
+			&i.MilestoneID,
+			&i.ComponentID,
+			&i.DeletedAt,
+			&i.VoteCount,
+			&i.DueAt,
+			&i.EstimateMinutes,
+			&i.Resolution,
+			&i.ReopenCount,
+			&i.LastReopenedAt,
+			&i.MergedIntoID,
+			&i.Confidential,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBugs = `-- name: ListBugs :many
+SELECT id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential FROM bugs
+WHERE deleted_at IS NULL
+  AND ($1::text[] IS NULL OR severity = ANY($1::text[]))
+  AND ($2::text[] IS NULL OR priority = ANY($2::text[]))
+  AND ($3::text[] IS NULL OR id IN (
+    SELECT bug_labels.bug_id FROM bug_labels
+    JOIN labels ON labels.id = bug_labels.label_id
+    WHERE labels.name = ANY($3::text[])
+    GROUP BY bug_labels.bug_id
+    HAVING COUNT(DISTINCT labels.name) = cardinality($3::text[])
+  ))
+  AND ($4::uuid IS NULL OR project_id = $4)
+  AND ($5::text[] IS NULL OR id IN (
+    SELECT bug_field_values.bug_id FROM bug_field_values
+    JOIN custom_fields ON custom_fields.id = bug_field_values.field_id
+    JOIN unnest($5::text[], $6::text[]) AS wanted (name, value)
+        ON wanted.name = custom_fields.name AND wanted.value = bug_field_values.value
+    GROUP BY bug_field_values.bug_id
+    HAVING COUNT(*) = cardinality($5::text[])
+  ))
+  AND ($7::text IS NULL OR id IN (
+    SELECT sla_bugs.id FROM bugs AS sla_bugs
+    LEFT JOIN sla_policies ON sla_policies.priority = sla_bugs.priority
+    CROSS JOIN LATERAL (
+        SELECT COALESCE(sla_bugs.due_at, sla_bugs.created_at + make_interval(hours => sla_policies.resolve_within_hours)) AS deadline
+    ) AS sla
+    WHERE sla_bugs.status NOT IN ('resolved', 'closed')
+      AND sla.deadline IS NOT NULL
+      AND CASE
+        WHEN NOW() >= sla.deadline THEN 'breached'
+        WHEN NOW() >= sla.deadline - (sla.deadline - sla_bugs.created_at) / 5 THEN 'at_risk'
+        ELSE 'ok'
+      END = $7::text
+  ))
+  AND (NOT confidential OR $8::boolean OR posted_by = $9 OR assignee_id = $9)
+ORDER BY
+    CASE WHEN $10::text = 'priority' THEN priority END ASC,
+    CASE WHEN $10::text = 'severity' THEN array_position(ARRAY['blocker', 'critical', 'major', 'minor', 'trivial'], severity) END ASC,
+    CASE WHEN $10::text = 'votes' THEN vote_count END DESC,
+    created_at DESC
+`
+
+type ListBugsParams struct {
+	Severities      []string
+	Priorities      []string
+	Labels          []string
+	ProjectID       uuid.NullUUID
+	FieldNames      []string
+	FieldValues     []string
+	Sla             sql.NullString
+	SeeConfidential bool
+	ViewerID        uuid.NullUUID
+	Sort            string
+}
+
+func (q *Queries) ListBugs(ctx context.Context, arg ListBugsParams) ([]Bug, error) {
+	rows, err := q.db.QueryContext(ctx, listBugs,
+		pq.Array(arg.Severities),
+		pq.Array(arg.Priorities),
+		pq.Array(arg.Labels),
+		arg.ProjectID,
+		pq.Array(arg.FieldNames),
+		pq.Array(arg.FieldValues),
+		arg.Sla,
+		arg.SeeConfidential,
+		arg.ViewerID,
+		arg.Sort,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Bug
+	for rows.Next() {
+		var i Bug
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.PostedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.Severity,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ProjectID,
+			&i.Number,
+			&i.Key,
+			&i.MilestoneID,
+			&i.ComponentID,
+			&i.DeletedAt,
+			&i.VoteCount,
+			&i.DueAt,
+			&i.EstimateMinutes,
+			&i.Resolution,
+			&i.ReopenCount,
+			&i.LastReopenedAt,
+			&i.MergedIntoID,
+			&i.Confidential,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedBugs = `-- name: PurgeDeletedBugs :execrows
+DELETE FROM bugs
+WHERE deleted_at IS NOT NULL AND deleted_at < $1::timestamp
+`
+
+func (q *Queries) PurgeDeletedBugs(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedBugs, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreBug = `-- name: RestoreBug :one
+UPDATE bugs
+SET
+    deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, title, description, posted_by, created_at, updated_at, status, severity, priority, assignee_id, project_id, number, key, milestone_id, component_id, deleted_at, vote_count, due_at, estimate_minutes, resolution, reopen_count, last_reopened_at, merged_into_id, confidential
+`
+
+func (q *Queries) RestoreBug(ctx context.Context, id uuid.UUID) (Bug, error) {
+	row := q.db.QueryRowContext(ctx, restoreBug, id)
+	var i Bug
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.PostedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.Severity,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ProjectID,
+		&i.Number,
+		&i.Key,
+		&i.MilestoneID,
+		&i.ComponentID,
+		&i.DeletedAt,
+		&i.VoteCount,
+		&i.DueAt,
+		&i.EstimateMinutes,
+		&i.Resolution,
+		&i.ReopenCount,
+		&i.LastReopenedAt,
+		&i.MergedIntoID,
+		&i.Confidential,
+	)
+	return i, err
+}
+
+const setBugAssignee = `-- name: SetBugAssignee :exec
+UPDATE bugs
+SET
+    assignee_id = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type SetBugAssigneeParams struct {
+	ID         uuid.UUID
+	AssigneeID uuid.NullUUID
+}
+
+func (q *Queries) SetBugAssignee(ctx context.Context, arg SetBugAssigneeParams) error {
+	_, err := q.db.ExecContext(ctx, setBugAssignee, arg.ID, arg.AssigneeID)
+	return err
+}
+
+const setBugComponent = `-- name: SetBugComponent :exec
+UPDATE bugs
+SET
+    component_id = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type SetBugComponentParams struct {
+	ID          uuid.UUID
+	ComponentID uuid.NullUUID
+}
+
+func (q *Queries) SetBugComponent(ctx context.Context, arg SetBugComponentParams) error {
+	_, err := q.db.ExecContext(ctx, setBugComponent, arg.ID, arg.ComponentID)
+	return err
+}
+
+const setBugConfidential = `-- name: SetBugConfidential :exec
+UPDATE bugs
+SET
+    confidential = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type SetBugConfidentialParams struct {
+	ID           uuid.UUID
+	Confidential bool
+}
+
+func (q *Queries) SetBugConfidential(ctx context.Context, arg SetBugConfidentialParams) error {
+	_, err := q.db.ExecContext(ctx, setBugConfidential, arg.ID, arg.Confidential)
+	return err
+}
+
+const setBugDueAt = `-- name: SetBugDueAt :exec
+UPDATE bugs
+SET
+    due_at = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type SetBugDueAtParams struct {
+	ID    uuid.UUID
+	DueAt sql.NullTime
+}
+
+func (q *Queries) SetBugDueAt(ctx context.Context, arg SetBugDueAtParams) error {
+	_, err := q.db.ExecContext(ctx, setBugDueAt, arg.ID, arg.DueAt)
+	return err
+}
+
+const setBugEstimate = `-- name: SetBugEstimate :exec
+UPDATE bugs
+SET
+    estimate_minutes = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type SetBugEstimateParams struct {
+	ID              uuid.UUID
+	EstimateMinutes sql.NullInt32
+}
+
+func (q *Queries) SetBugEstimate(ctx context.Context, arg SetBugEstimateParams) error {
+	_, err := q.db.ExecContext(ctx, setBugEstimate, arg.ID, arg.EstimateMinutes)
+	return err
+}
+
+const setBugMergedInto = `-- name: SetBugMergedInto :exec
+UPDATE bugs
+SET
+    merged_into_id = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type SetBugMergedIntoParams struct {
+	ID           uuid.UUID
+	MergedIntoID uuid.NullUUID
+}
+
+func (q *Queries) SetBugMergedInto(ctx context.Context, arg SetBugMergedIntoParams) error {
+	_, err := q.db.ExecContext(ctx, setBugMergedInto, arg.ID, arg.MergedIntoID)
+	return err
+}
+
+const setBugMilestone = `-- name: SetBugMilestone :exec
+UPDATE bugs
+SET
+    milestone_id = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type SetBugMilestoneParams struct {
+	ID          uuid.UUID
+	MilestoneID uuid.NullUUID
+}
+
+func (q *Queries) SetBugMilestone(ctx context.Context, arg SetBugMilestoneParams) error {
+	_, err := q.db.ExecContext(ctx, setBugMilestone, arg.ID, arg.MilestoneID)
+	return err
+}
+
+const setBugResolution = `-- name: SetBugResolution :execrows
+UPDATE bugs
+SET
+    resolution = $2,
+    updated_at = NOW()
+WHERE id = $1 AND status = $3
+`
+
+type SetBugResolutionParams struct {
+	ID         uuid.UUID
+	Resolution sql.NullString
+	Status     string
+}
+
+func (q *Queries) SetBugResolution(ctx context.Context, arg SetBugResolutionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setBugResolution, arg.ID, arg.Resolution, arg.Status)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const softDeleteBug = `-- name: SoftDeleteBug :one
+UPDATE bugs
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING deleted_at
+`
+
+func (q *Queries) SoftDeleteBug(ctx context.Context, id uuid.UUID) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, softDeleteBug, id)
+	var deleted_at sql.NullTime
+	err := row.Scan(&deleted_at)
+	return deleted_at, err
+}
+
 const updateBugByID = `-- name: UpdateBugByID :exec
 UPDATE bugs
 SET 
     title = COALESCE($2, title),
     description = COALESCE($3, description),
+    severity = COALESCE($4, severity),
+    priority = COALESCE($5, priority),
     updated_at = Now()
 WHERE id = $1
 `
@@ -123,9 +786,48 @@ type UpdateBugByIDParams struct {
 	ID          uuid.UUID
 	Title       sql.NullString
 	Description sql.NullString
+	Severity    sql.NullString
+	Priority    sql.NullString
 }
 
 func (q *Queries) UpdateBugByID(ctx context.Context, arg UpdateBugByIDParams) error {
-	_, err := q.db.ExecContext(ctx, updateBugByID, arg.ID, arg.Title, arg.Description)
+	_, err := q.db.ExecContext(ctx, updateBugByID,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.Severity,
+		arg.Priority,
+	)
 	return err
 }
+
+const updateBugStatus = `-- name: UpdateBugStatus :execrows
+UPDATE bugs
+SET
+    status = $2,
+    resolution = $3,
+    reopen_count = CASE WHEN $2 = 'reopened' THEN reopen_count + 1 ELSE reopen_count END,
+    last_reopened_at = CASE WHEN $2 = 'reopened' THEN NOW() ELSE last_reopened_at END,
+    updated_at = NOW()
+WHERE id = $1 AND status = $4
+`
+
+type UpdateBugStatusParams struct {
+	ID         uuid.UUID
+	ToStatus   string
+	Resolution sql.NullString
+	FromStatus string
+}
+
+func (q *Queries) UpdateBugStatus(ctx context.Context, arg UpdateBugStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateBugStatus,
+		arg.ID,
+		arg.ToStatus,
+		arg.Resolution,
+		arg.FromStatus,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -11,15 +11,136 @@ import (
 	"github.com/google/uuid"
 )
 
+type Attachment struct {
+	ID          uuid.UUID
+	BugID       uuid.UUID
+	UploadedBy  uuid.UUID
+	Filename    string
+	ContentType string
+	SizeBytes   int64
+	Sha256      string
+	CreatedAt   time.Time
+}
+
 type Bug struct {
+	ID              uuid.UUID
+	Title           string
+	Description     string
+	PostedBy        uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Status          string
+	Severity        string
+	Priority        string
+	AssigneeID      uuid.NullUUID
+	ProjectID       uuid.UUID
+	Number          int32
+	Key             string
+	MilestoneID     uuid.NullUUID
+	ComponentID     uuid.NullUUID
+	DeletedAt       sql.NullTime
+	VoteCount       int32
+	DueAt           sql.NullTime
+	EstimateMinutes sql.NullInt32
+	Resolution      sql.NullString
+	ReopenCount     int32
+	LastReopenedAt  sql.NullTime
+	MergedIntoID    uuid.NullUUID
+	Confidential    bool
+}
+
+type BugEvent struct {
+	ID        uuid.UUID
+	BugID     uuid.UUID
+	ActorID   uuid.UUID
+	Field     string
+	OldValue  sql.NullString
+	NewValue  sql.NullString
+	CreatedAt time.Time
+}
+
+type BugFieldValue struct {
+	BugID   uuid.UUID
+	FieldID uuid.UUID
+	Value   string
+}
+
+type BugLabel struct {
+	BugID   uuid.UUID
+	LabelID uuid.UUID
+}
+
+type BugLink struct {
+	ID        uuid.UUID
+	SourceID  uuid.UUID
+	TargetID  uuid.UUID
+	LinkType  string
+	CreatedBy uuid.UUID
+	CreatedAt time.Time
+}
+
+type BugStatusTransition struct {
+	ID         uuid.UUID
+	BugID      uuid.UUID
+	FromStatus string
+	ToStatus   string
+	ChangedBy  uuid.UUID
+	ChangedAt  time.Time
+}
+
+type BugTemplate struct {
+	ID             uuid.UUID
+	ProjectID      uuid.UUID
+	Name           string
+	Description    string
+	Labels         []string
+	RequiredFields []string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type BugVote struct {
+	BugID     uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+type BugWatcher struct {
+	BugID     uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+type Comment struct {
+	ID        uuid.UUID
+	BugID     uuid.UUID
+	AuthorID  uuid.UUID
+	ParentID  uuid.NullUUID
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	EditedAt  sql.NullTime
+}
+
+type Component struct {
 	ID          uuid.UUID
-	Title       string
+	ProjectID   uuid.UUID
+	Name        string
 	Description string
-	PostedBy    uuid.UUID
+	OwnerID     uuid.NullUUID
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
+type CustomField struct {
+	ID        uuid.UUID
+	ProjectID uuid.UUID
+	Name      string
+	FieldType string
+	Options   []string
+	CreatedAt time.Time
+}
+
 type GooseDbVersion struct {
 	ID        int32
 	VersionID int64
@@ -27,6 +148,45 @@ type GooseDbVersion struct {
 	Tstamp    time.Time
 }
 
+type Label struct {
+	ID          uuid.UUID
+	Name        string
+	Color       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Mention struct {
+	ID          uuid.UUID
+	BugID       uuid.UUID
+	CommentID   uuid.NullUUID
+	UserID      uuid.UUID
+	MentionedBy uuid.UUID
+	CreatedAt   time.Time
+}
+
+type Milestone struct {
+	ID        uuid.UUID
+	ProjectID uuid.UUID
+	Name      string
+	DueDate   sql.NullTime
+	State     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Project struct {
+	ID            uuid.UUID
+	Key           string
+	Name          string
+	Description   string
+	OwnerID       uuid.NullUUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	LastBugNumber int32
+}
+
 type RefreshToken struct {
 	Token     string
 	CreatedAt time.Time
@@ -36,6 +196,12 @@ type RefreshToken struct {
 	RevokedAt sql.NullTime
 }
 
+type SlaPolicy struct {
+	Priority           string
+	ResolveWithinHours int32
+	UpdatedAt          time.Time
+}
+
 type User struct {
 	ID             uuid.UUID
 	CreatedAt      time.Time
@@ -44,3 +210,14 @@ type User struct {
 	HashedPassword string
 	Role           string
 }
+
+type WorkLog struct {
+	ID        uuid.UUID
+	BugID     uuid.UUID
+	UserID    uuid.UUID
+	Minutes   int32
+	Note      string
+	WorkDate  time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
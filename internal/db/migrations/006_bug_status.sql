@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE bugs
+ADD COLUMN status TEXT NOT NULL DEFAULT 'open'
+CHECK (status IN ('open', 'triaged', 'in_progress', 'resolved', 'closed', 'reopened'));
+
+CREATE TABLE bug_status_transitions (
+    id UUID PRIMARY KEY,
+    bug_id UUID NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    changed_by UUID NOT NULL,
+    changed_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (changed_by) REFERENCES users(id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS bug_status_transitions;
+ALTER TABLE bugs
+DROP COLUMN status;
@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE bugs
+ADD COLUMN severity TEXT NOT NULL DEFAULT 'major'
+CHECK (severity IN ('blocker', 'critical', 'major', 'minor', 'trivial'));
+
+ALTER TABLE bugs
+ADD COLUMN priority TEXT NOT NULL DEFAULT 'P2'
+CHECK (priority IN ('P0', 'P1', 'P2', 'P3', 'P4'));
+
+CREATE INDEX bugs_severity_idx ON bugs (severity);
+CREATE INDEX bugs_priority_idx ON bugs (priority);
+
+-- +goose Down
+DROP INDEX IF EXISTS bugs_priority_idx;
+DROP INDEX IF EXISTS bugs_severity_idx;
+ALTER TABLE bugs
+DROP COLUMN priority;
+ALTER TABLE bugs
+DROP COLUMN severity;
@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE bugs
+ADD COLUMN assignee_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX bugs_assignee_id_idx ON bugs (assignee_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS bugs_assignee_id_idx;
+ALTER TABLE bugs
+DROP COLUMN assignee_id;
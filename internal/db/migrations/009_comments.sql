@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE comments (
+    id UUID PRIMARY KEY,
+    bug_id UUID NOT NULL,
+    author_id UUID NOT NULL,
+    parent_id UUID,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    edited_at TIMESTAMP,
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users(id),
+    FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE
+);
+
+CREATE INDEX comments_bug_id_idx ON comments (bug_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS comments;
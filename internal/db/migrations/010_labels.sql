@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE labels (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    color TEXT NOT NULL DEFAULT '#cccccc',
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE bug_labels (
+    bug_id UUID NOT NULL,
+    label_id UUID NOT NULL,
+    PRIMARY KEY (bug_id, label_id),
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (label_id) REFERENCES labels(id) ON DELETE CASCADE
+);
+
+CREATE INDEX bug_labels_label_id_idx ON bug_labels (label_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS bug_labels;
+DROP TABLE IF EXISTS labels;
@@ -0,0 +1,36 @@
+-- +goose Up
+CREATE TABLE projects (
+    id UUID PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    owner_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (owner_id) REFERENCES users(id)
+);
+
+-- Existing bugs move into a default project owned by the oldest admin (or the oldest
+-- user when there is no admin yet). On an empty database there is nothing to move.
+INSERT INTO projects (id, key, name, description, owner_id, created_at, updated_at)
+SELECT gen_random_uuid(), 'BUG', 'Default project', 'Bugs filed before projects existed', id, NOW(), NOW()
+FROM users
+ORDER BY (role = 'admin') DESC, created_at ASC
+LIMIT 1;
+
+ALTER TABLE bugs
+ADD COLUMN project_id UUID REFERENCES projects(id);
+
+UPDATE bugs
+SET project_id = (SELECT id FROM projects WHERE key = 'BUG');
+
+ALTER TABLE bugs
+ALTER COLUMN project_id SET NOT NULL;
+
+CREATE INDEX bugs_project_id_idx ON bugs (project_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS bugs_project_id_idx;
+ALTER TABLE bugs
+DROP COLUMN project_id;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,33 @@
+-- +goose Up
+ALTER TABLE projects
+ADD COLUMN last_bug_number INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE bugs
+ADD COLUMN number INTEGER,
+ADD COLUMN key TEXT;
+
+-- Existing bugs are numbered in the order they were filed.
+UPDATE bugs
+SET number = numbered.number,
+    key = projects.key || '-' || numbered.number
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id ORDER BY created_at, id) AS number
+    FROM bugs
+) AS numbered, projects
+WHERE numbered.id = bugs.id AND projects.id = bugs.project_id;
+
+UPDATE projects
+SET last_bug_number = COALESCE((SELECT MAX(number) FROM bugs WHERE bugs.project_id = projects.id), 0);
+
+ALTER TABLE bugs
+ALTER COLUMN number SET NOT NULL,
+ALTER COLUMN key SET NOT NULL,
+ADD CONSTRAINT bugs_project_id_number_key UNIQUE (project_id, number),
+ADD CONSTRAINT bugs_key_key UNIQUE (key);
+
+-- +goose Down
+ALTER TABLE bugs
+DROP COLUMN key,
+DROP COLUMN number;
+ALTER TABLE projects
+DROP COLUMN last_bug_number;
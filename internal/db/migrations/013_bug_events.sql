@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE bug_events (
+    id UUID PRIMARY KEY,
+    bug_id UUID NOT NULL,
+    actor_id UUID NOT NULL,
+    field TEXT NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id)
+);
+
+CREATE INDEX bug_events_bug_id_idx ON bug_events (bug_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS bug_events;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE bug_links (
+    id UUID PRIMARY KEY,
+    source_id UUID NOT NULL,
+    target_id UUID NOT NULL,
+    link_type TEXT NOT NULL CHECK (link_type IN ('duplicate-of', 'blocks', 'relates-to', 'parent-of')),
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (source_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (target_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id),
+    UNIQUE (source_id, target_id, link_type),
+    CHECK (source_id <> target_id)
+);
+
+-- A bug can only be a duplicate of one canonical bug.
+CREATE UNIQUE INDEX bug_links_duplicate_of_idx ON bug_links (source_id) WHERE link_type = 'duplicate-of';
+CREATE INDEX bug_links_target_id_idx ON bug_links (target_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS bug_links;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE attachments (
+    id UUID PRIMARY KEY,
+    bug_id UUID NOT NULL,
+    uploaded_by UUID NOT NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    sha256 TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (uploaded_by) REFERENCES users(id),
+    UNIQUE (bug_id, sha256)
+);
+
+CREATE INDEX attachments_sha256_idx ON attachments (sha256);
+
+-- +goose Down
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE bug_watchers (
+    bug_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (bug_id, user_id),
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX bug_watchers_user_id_idx ON bug_watchers (user_id);
+
+-- Authors and assignees of existing bugs watch them, as they would for new bugs.
+INSERT INTO bug_watchers (bug_id, user_id, created_at)
+SELECT id, posted_by, NOW() FROM bugs
+UNION
+SELECT id, assignee_id, NOW() FROM bugs WHERE assignee_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS bug_watchers;
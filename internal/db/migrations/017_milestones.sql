@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE milestones (
+    id UUID PRIMARY KEY,
+    project_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    due_date DATE,
+    state TEXT NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    UNIQUE (project_id, name),
+    CONSTRAINT milestones_state_check CHECK (state IN ('open', 'closed'))
+);
+
+ALTER TABLE bugs
+ADD COLUMN milestone_id UUID REFERENCES milestones(id) ON DELETE SET NULL;
+
+CREATE INDEX bugs_milestone_id_idx ON bugs (milestone_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS bugs_milestone_id_idx;
+ALTER TABLE bugs
+DROP COLUMN milestone_id;
+DROP TABLE IF EXISTS milestones;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE components (
+    id UUID PRIMARY KEY,
+    project_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    owner_id UUID,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE SET NULL,
+    UNIQUE (project_id, name)
+);
+
+ALTER TABLE bugs
+ADD COLUMN component_id UUID REFERENCES components(id) ON DELETE SET NULL;
+
+CREATE INDEX bugs_component_id_idx ON bugs (component_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS bugs_component_id_idx;
+ALTER TABLE bugs
+DROP COLUMN component_id;
+DROP TABLE IF EXISTS components;
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE custom_fields (
+    id UUID PRIMARY KEY,
+    project_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    field_type TEXT NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    UNIQUE (project_id, name),
+    CONSTRAINT custom_fields_field_type_check CHECK (field_type IN ('text', 'number', 'enum', 'date', 'user'))
+);
+
+-- Values are kept in a canonical text form (numbers without trailing zeros, dates as
+-- YYYY-MM-DD, users as their id) so that filtering is a plain comparison.
+CREATE TABLE bug_field_values (
+    bug_id UUID NOT NULL,
+    field_id UUID NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (bug_id, field_id),
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (field_id) REFERENCES custom_fields(id) ON DELETE CASCADE
+);
+
+CREATE INDEX bug_field_values_field_id_value_idx ON bug_field_values (field_id, value);
+
+-- +goose Down
+DROP TABLE IF EXISTS bug_field_values;
+DROP TABLE IF EXISTS custom_fields;
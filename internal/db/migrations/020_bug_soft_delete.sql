@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE bugs
+ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX bugs_deleted_at_idx ON bugs (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS bugs_deleted_at_idx;
+ALTER TABLE bugs
+DROP COLUMN deleted_at;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE mentions (
+    id UUID PRIMARY KEY,
+    bug_id UUID NOT NULL,
+    comment_id UUID,
+    user_id UUID NOT NULL,
+    mentioned_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (mentioned_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- A user is mentioned at most once per bug description and once per comment, so
+-- editing text that keeps a mention does not record it again.
+CREATE UNIQUE INDEX mentions_bug_id_user_id_idx ON mentions (bug_id, user_id) WHERE comment_id IS NULL;
+CREATE UNIQUE INDEX mentions_comment_id_user_id_idx ON mentions (comment_id, user_id) WHERE comment_id IS NOT NULL;
+CREATE INDEX mentions_user_id_idx ON mentions (user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS mentions;
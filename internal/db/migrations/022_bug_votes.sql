@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE bug_votes (
+    bug_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (bug_id, user_id),
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- vote_count mirrors the number of rows in bug_votes so listings can show and sort by
+-- it without counting; it is only changed together with bug_votes.
+ALTER TABLE bugs
+ADD COLUMN vote_count INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE bugs
+DROP COLUMN vote_count;
+DROP TABLE IF EXISTS bug_votes;
@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE bugs
+ADD COLUMN due_at TIMESTAMP;
+
+CREATE TABLE sla_policies (
+    priority TEXT PRIMARY KEY,
+    resolve_within_hours INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT sla_policies_priority_check CHECK (priority IN ('P0', 'P1', 'P2', 'P3', 'P4')),
+    CONSTRAINT sla_policies_resolve_within_hours_check CHECK (resolve_within_hours > 0)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS sla_policies;
+ALTER TABLE bugs
+DROP COLUMN due_at;
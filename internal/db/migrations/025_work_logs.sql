@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE work_logs (
+    id UUID PRIMARY KEY,
+    bug_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    minutes INTEGER NOT NULL CHECK (minutes > 0),
+    note TEXT NOT NULL DEFAULT '',
+    work_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX work_logs_bug_id_idx ON work_logs (bug_id);
+CREATE INDEX work_logs_work_date_idx ON work_logs (work_date);
+
+ALTER TABLE bugs
+ADD COLUMN estimate_minutes INTEGER CHECK (estimate_minutes > 0);
+
+-- +goose Down
+ALTER TABLE bugs
+DROP COLUMN estimate_minutes;
+DROP TABLE IF EXISTS work_logs;
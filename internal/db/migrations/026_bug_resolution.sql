@@ -0,0 +1,33 @@
+-- +goose Up
+ALTER TABLE bugs
+ADD COLUMN resolution TEXT
+CHECK (resolution IN ('fixed', 'wont_fix', 'duplicate', 'cannot_reproduce', 'works_as_intended')),
+ADD COLUMN reopen_count INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN last_reopened_at TIMESTAMP;
+
+-- Bugs closed as duplicates before resolutions existed are the only ones we can classify.
+UPDATE bugs
+SET resolution = 'duplicate'
+WHERE status IN ('resolved', 'closed')
+  AND id IN (SELECT source_id FROM bug_links WHERE link_type = 'duplicate-of');
+
+UPDATE bugs
+SET
+    reopen_count = reopens.count,
+    last_reopened_at = reopens.last_at
+FROM (
+    SELECT bug_id, COUNT(*) AS count, MAX(changed_at) AS last_at
+    FROM bug_status_transitions
+    WHERE to_status = 'reopened'
+    GROUP BY bug_id
+) AS reopens
+WHERE bugs.id = reopens.bug_id;
+
+CREATE INDEX bugs_reopen_count_idx ON bugs (reopen_count) WHERE reopen_count > 0;
+
+-- +goose Down
+DROP INDEX IF EXISTS bugs_reopen_count_idx;
+ALTER TABLE bugs
+DROP COLUMN last_reopened_at,
+DROP COLUMN reopen_count,
+DROP COLUMN resolution;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE bugs
+ADD COLUMN merged_into_id UUID REFERENCES bugs(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE bugs
+DROP COLUMN merged_into_id;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE bugs
+ADD COLUMN confidential BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE bugs
+DROP COLUMN confidential;
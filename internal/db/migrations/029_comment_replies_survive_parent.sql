@@ -0,0 +1,13 @@
+-- +goose Up
+-- Deleting a comment must not take other users' replies with it; they become
+-- top-level comments instead.
+ALTER TABLE comments
+DROP CONSTRAINT comments_parent_id_fkey,
+ADD CONSTRAINT comments_parent_id_fkey
+    FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE comments
+DROP CONSTRAINT comments_parent_id_fkey,
+ADD CONSTRAINT comments_parent_id_fkey
+    FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE;
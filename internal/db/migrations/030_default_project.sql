@@ -0,0 +1,26 @@
+-- +goose Up
+-- 011 only created the default project when a user existed, so on a fresh install
+-- bugs filed without project_key had nowhere to go. Projects may now be unowned;
+-- admins manage those.
+ALTER TABLE projects
+ALTER COLUMN owner_id DROP NOT NULL;
+
+INSERT INTO projects (id, key, name, description, owner_id, created_at, updated_at)
+VALUES (gen_random_uuid(), 'BUG', 'Default project', 'Bugs filed without a project', NULL, NOW(), NOW())
+ON CONFLICT (key) DO NOTHING;
+
+-- +goose Down
+UPDATE projects
+SET owner_id = (
+    SELECT id FROM users
+    ORDER BY (role = 'admin') DESC, created_at ASC
+    LIMIT 1
+)
+WHERE owner_id IS NULL;
+
+-- Without any user the unowned default project cannot have bugs yet.
+DELETE FROM projects
+WHERE owner_id IS NULL;
+
+ALTER TABLE projects
+ALTER COLUMN owner_id SET NOT NULL;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Deleting a user cascades to their rows in bug_votes without going through the
+-- handlers that keep bugs.vote_count in step, so the count is lowered here before the
+-- votes go.
+-- +goose StatementBegin
+CREATE FUNCTION release_user_votes() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    UPDATE bugs SET vote_count = vote_count - 1
+    WHERE id IN (SELECT bug_id FROM bug_votes WHERE user_id = OLD.id);
+    RETURN OLD;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER users_release_votes
+BEFORE DELETE ON users
+FOR EACH ROW EXECUTE FUNCTION release_user_votes();
+
+-- Repair counts left behind by users deleted before the trigger existed.
+UPDATE bugs
+SET vote_count = (SELECT COUNT(*) FROM bug_votes WHERE bug_votes.bug_id = bugs.id)
+WHERE vote_count <> (SELECT COUNT(*) FROM bug_votes WHERE bug_votes.bug_id = bugs.id);
+
+-- +goose Down
+DROP TRIGGER IF EXISTS users_release_votes ON users;
+DROP FUNCTION IF EXISTS release_user_votes();
@@ -0,0 +1,13 @@
+-- +goose Up
+-- created_at is filled in by NOW() in the session's time zone while the API writes
+-- due_at in UTC, so SLA deadlines were off by the zone offset whenever the database
+-- did not run in UTC. Stored as timestamptz both are instants. Existing created_at
+-- values are read in the session's zone, the one NOW() wrote them in.
+ALTER TABLE bugs
+ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at,
+ALTER COLUMN due_at TYPE TIMESTAMPTZ USING due_at AT TIME ZONE 'UTC';
+
+-- +goose Down
+ALTER TABLE bugs
+ALTER COLUMN created_at TYPE TIMESTAMP USING created_at,
+ALTER COLUMN due_at TYPE TIMESTAMP USING due_at AT TIME ZONE 'UTC';
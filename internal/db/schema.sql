@@ -16,10 +16,145 @@ SET xmloption = content;
 SET client_min_messages = warning;
 SET row_security = off;
 
+--
+-- Name: release_user_votes(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE FUNCTION public.release_user_votes() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    UPDATE bugs SET vote_count = vote_count - 1
+    WHERE id IN (SELECT bug_id FROM bug_votes WHERE user_id = OLD.id);
+    RETURN OLD;
+END;
+$$;
+
+
 SET default_tablespace = '';
 
 SET default_table_access_method = heap;
 
+--
+-- Name: attachments; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.attachments (
+    id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    uploaded_by uuid NOT NULL,
+    filename text NOT NULL,
+    content_type text NOT NULL,
+    size_bytes bigint NOT NULL,
+    sha256 text NOT NULL,
+    created_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: bug_events; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.bug_events (
+    id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    actor_id uuid NOT NULL,
+    field text NOT NULL,
+    old_value text,
+    new_value text,
+    created_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: bug_field_values; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.bug_field_values (
+    bug_id uuid NOT NULL,
+    field_id uuid NOT NULL,
+    value text NOT NULL
+);
+
+
+--
+-- Name: bug_labels; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.bug_labels (
+    bug_id uuid NOT NULL,
+    label_id uuid NOT NULL
+);
+
+
+--
+-- Name: bug_links; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.bug_links (
+    id uuid NOT NULL,
+    source_id uuid NOT NULL,
+    target_id uuid NOT NULL,
+    link_type text NOT NULL,
+    created_by uuid NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    CONSTRAINT bug_links_check CHECK ((source_id <> target_id)),
+    CONSTRAINT bug_links_link_type_check CHECK ((link_type = ANY (ARRAY['duplicate-of'::text, 'blocks'::text, 'relates-to'::text, 'parent-of'::text])))
+);
+
+
+--
+-- Name: bug_status_transitions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.bug_status_transitions (
+    id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    from_status text NOT NULL,
+    to_status text NOT NULL,
+    changed_by uuid NOT NULL,
+    changed_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: bug_templates; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.bug_templates (
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    name text NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    labels text[] DEFAULT '{}'::text[] NOT NULL,
+    required_fields text[] DEFAULT '{}'::text[] NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: bug_votes; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.bug_votes (
+    bug_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    created_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: bug_watchers; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.bug_watchers (
+    bug_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    created_at timestamp without time zone NOT NULL
+);
+
+
 --
 -- Name: bugs; Type: TABLE; Schema: public; Owner: -
 --
@@ -29,11 +164,80 @@ CREATE TABLE public.bugs (
     title text NOT NULL,
     description text NOT NULL,
     posted_by uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    status text DEFAULT 'open'::text NOT NULL,
+    severity text DEFAULT 'major'::text NOT NULL,
+    priority text DEFAULT 'P2'::text NOT NULL,
+    assignee_id uuid,
+    project_id uuid NOT NULL,
+    number integer NOT NULL,
+    key text NOT NULL,
+    milestone_id uuid,
+    component_id uuid,
+    deleted_at timestamp without time zone,
+    vote_count integer DEFAULT 0 NOT NULL,
+    due_at timestamp with time zone,
+    estimate_minutes integer,
+    resolution text,
+    reopen_count integer DEFAULT 0 NOT NULL,
+    last_reopened_at timestamp without time zone,
+    merged_into_id uuid,
+    confidential boolean DEFAULT false NOT NULL,
+    CONSTRAINT bugs_estimate_minutes_check CHECK ((estimate_minutes > 0)),
+    CONSTRAINT bugs_priority_check CHECK ((priority = ANY (ARRAY['P0'::text, 'P1'::text, 'P2'::text, 'P3'::text, 'P4'::text]))),
+    CONSTRAINT bugs_resolution_check CHECK ((resolution = ANY (ARRAY['fixed'::text, 'wont_fix'::text, 'duplicate'::text, 'cannot_reproduce'::text, 'works_as_intended'::text]))),
+    CONSTRAINT bugs_severity_check CHECK ((severity = ANY (ARRAY['blocker'::text, 'critical'::text, 'major'::text, 'minor'::text, 'trivial'::text]))),
+    CONSTRAINT bugs_status_check CHECK ((status = ANY (ARRAY['open'::text, 'triaged'::text, 'in_progress'::text, 'resolved'::text, 'closed'::text, 'reopened'::text])))
+);
+
+
+--
+-- Name: comments; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.comments (
+    id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    author_id uuid NOT NULL,
+    parent_id uuid,
+    body text NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    edited_at timestamp without time zone
+);
+
+
+--
+-- Name: components; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.components (
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    name text NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    owner_id uuid,
     created_at timestamp without time zone NOT NULL,
     updated_at timestamp without time zone NOT NULL
 );
 
 
+--
+-- Name: custom_fields; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.custom_fields (
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    name text NOT NULL,
+    field_type text NOT NULL,
+    options text[] DEFAULT '{}'::text[] NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    CONSTRAINT custom_fields_field_type_check CHECK ((field_type = ANY (ARRAY['text'::text, 'number'::text, 'enum'::text, 'date'::text, 'user'::text])))
+);
+
+
 --
 -- Name: goose_db_version; Type: TABLE; Schema: public; Owner: -
 --
@@ -60,6 +264,66 @@ ALTER TABLE public.goose_db_version ALTER COLUMN id ADD GENERATED BY DEFAULT AS
 );
 
 
+--
+-- Name: labels; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.labels (
+    id uuid NOT NULL,
+    name text NOT NULL,
+    color text DEFAULT '#cccccc'::text NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: mentions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.mentions (
+    id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    comment_id uuid,
+    user_id uuid NOT NULL,
+    mentioned_by uuid NOT NULL,
+    created_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: milestones; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.milestones (
+    id uuid NOT NULL,
+    project_id uuid NOT NULL,
+    name text NOT NULL,
+    due_date date,
+    state text DEFAULT 'open'::text NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    CONSTRAINT milestones_state_check CHECK ((state = ANY (ARRAY['open'::text, 'closed'::text])))
+);
+
+
+--
+-- Name: projects; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.projects (
+    id uuid NOT NULL,
+    key text NOT NULL,
+    name text NOT NULL,
+    description text DEFAULT ''::text NOT NULL,
+    owner_id uuid,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    last_bug_number integer DEFAULT 0 NOT NULL
+);
+
+
 --
 -- Name: refresh_tokens; Type: TABLE; Schema: public; Owner: -
 --
@@ -74,6 +338,19 @@ CREATE TABLE public.refresh_tokens (
 );
 
 
+--
+-- Name: sla_policies; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.sla_policies (
+    priority text NOT NULL,
+    resolve_within_hours integer NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    CONSTRAINT sla_policies_priority_check CHECK ((priority = ANY (ARRAY['P0'::text, 'P1'::text, 'P2'::text, 'P3'::text, 'P4'::text]))),
+    CONSTRAINT sla_policies_resolve_within_hours_check CHECK ((resolve_within_hours > 0))
+);
+
+
 --
 -- Name: users; Type: TABLE; Schema: public; Owner: -
 --
@@ -88,6 +365,127 @@ CREATE TABLE public.users (
 );
 
 
+--
+-- Name: work_logs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.work_logs (
+    id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    minutes integer NOT NULL,
+    note text DEFAULT ''::text NOT NULL,
+    work_date date NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    CONSTRAINT work_logs_minutes_check CHECK ((minutes > 0))
+);
+
+
+--
+-- Name: attachments attachments_bug_id_sha256_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.attachments
+    ADD CONSTRAINT attachments_bug_id_sha256_key UNIQUE (bug_id, sha256);
+
+
+--
+-- Name: attachments attachments_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.attachments
+    ADD CONSTRAINT attachments_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: bug_events bug_events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_events
+    ADD CONSTRAINT bug_events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: bug_field_values bug_field_values_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_field_values
+    ADD CONSTRAINT bug_field_values_pkey PRIMARY KEY (bug_id, field_id);
+
+
+--
+-- Name: bug_labels bug_labels_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_labels
+    ADD CONSTRAINT bug_labels_pkey PRIMARY KEY (bug_id, label_id);
+
+
+--
+-- Name: bug_links bug_links_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_links
+    ADD CONSTRAINT bug_links_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: bug_links bug_links_source_id_target_id_link_type_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_links
+    ADD CONSTRAINT bug_links_source_id_target_id_link_type_key UNIQUE (source_id, target_id, link_type);
+
+
+--
+-- Name: bug_status_transitions bug_status_transitions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_status_transitions
+    ADD CONSTRAINT bug_status_transitions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: bug_templates bug_templates_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_templates
+    ADD CONSTRAINT bug_templates_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: bug_templates bug_templates_project_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_templates
+    ADD CONSTRAINT bug_templates_project_id_name_key UNIQUE (project_id, name);
+
+
+--
+-- Name: bug_votes bug_votes_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_votes
+    ADD CONSTRAINT bug_votes_pkey PRIMARY KEY (bug_id, user_id);
+
+
+--
+-- Name: bug_watchers bug_watchers_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_watchers
+    ADD CONSTRAINT bug_watchers_pkey PRIMARY KEY (bug_id, user_id);
+
+
+--
+-- Name: bugs bugs_key_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bugs
+    ADD CONSTRAINT bugs_key_key UNIQUE (key);
+
+
 --
 -- Name: bugs bugs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -96,6 +494,54 @@ ALTER TABLE ONLY public.bugs
     ADD CONSTRAINT bugs_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: bugs bugs_project_id_number_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bugs
+    ADD CONSTRAINT bugs_project_id_number_key UNIQUE (project_id, number);
+
+
+--
+-- Name: comments comments_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.comments
+    ADD CONSTRAINT comments_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: components components_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.components
+    ADD CONSTRAINT components_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: components components_project_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.components
+    ADD CONSTRAINT components_project_id_name_key UNIQUE (project_id, name);
+
+
+--
+-- Name: custom_fields custom_fields_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.custom_fields
+    ADD CONSTRAINT custom_fields_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: custom_fields custom_fields_project_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.custom_fields
+    ADD CONSTRAINT custom_fields_project_id_name_key UNIQUE (project_id, name);
+
+
 --
 -- Name: goose_db_version goose_db_version_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -105,45 +551,567 @@ ALTER TABLE ONLY public.goose_db_version
 
 
 --
--- Name: refresh_tokens refresh_tokens_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: labels labels_name_key; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.refresh_tokens
-    ADD CONSTRAINT refresh_tokens_pkey PRIMARY KEY (token);
+ALTER TABLE ONLY public.labels
+    ADD CONSTRAINT labels_name_key UNIQUE (name);
 
 
 --
--- Name: users users_email_key; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: labels labels_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE ONLY public.labels
+    ADD CONSTRAINT labels_pkey PRIMARY KEY (id);
 
 
 --
--- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: mentions mentions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.mentions
+    ADD CONSTRAINT mentions_pkey PRIMARY KEY (id);
 
 
 --
--- Name: bugs bugs_posted_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: milestones milestones_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.bugs
-    ADD CONSTRAINT bugs_posted_by_fkey FOREIGN KEY (posted_by) REFERENCES public.users(id);
+ALTER TABLE ONLY public.milestones
+    ADD CONSTRAINT milestones_pkey PRIMARY KEY (id);
 
 
 --
--- Name: refresh_tokens refresh_tokens_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: milestones milestones_project_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.milestones
+    ADD CONSTRAINT milestones_project_id_name_key UNIQUE (project_id, name);
+
+
+--
+-- Name: projects projects_key_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.projects
+    ADD CONSTRAINT projects_key_key UNIQUE (key);
+
+
+--
+-- Name: projects projects_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.projects
+    ADD CONSTRAINT projects_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: refresh_tokens refresh_tokens_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.refresh_tokens
+    ADD CONSTRAINT refresh_tokens_pkey PRIMARY KEY (token);
+
+
+--
+-- Name: sla_policies sla_policies_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.sla_policies
+    ADD CONSTRAINT sla_policies_pkey PRIMARY KEY (priority);
+
+
+--
+-- Name: users users_email_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_email_key UNIQUE (email);
+
+
+--
+-- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: work_logs work_logs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.work_logs
+    ADD CONSTRAINT work_logs_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: attachments_sha256_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX attachments_sha256_idx ON public.attachments USING btree (sha256);
+
+
+--
+-- Name: bug_events_bug_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bug_events_bug_id_idx ON public.bug_events USING btree (bug_id, created_at);
+
+
+--
+-- Name: bug_field_values_field_id_value_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bug_field_values_field_id_value_idx ON public.bug_field_values USING btree (field_id, value);
+
+
+--
+-- Name: bug_labels_label_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bug_labels_label_id_idx ON public.bug_labels USING btree (label_id);
+
+
+--
+-- Name: bug_links_duplicate_of_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX bug_links_duplicate_of_idx ON public.bug_links USING btree (source_id) WHERE (link_type = 'duplicate-of'::text);
+
+
+--
+-- Name: bug_links_target_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bug_links_target_id_idx ON public.bug_links USING btree (target_id);
+
+
+--
+-- Name: bug_watchers_user_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bug_watchers_user_id_idx ON public.bug_watchers USING btree (user_id);
+
+
+--
+-- Name: bugs_assignee_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bugs_assignee_id_idx ON public.bugs USING btree (assignee_id);
+
+
+--
+-- Name: bugs_component_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bugs_component_id_idx ON public.bugs USING btree (component_id);
+
+
+--
+-- Name: bugs_deleted_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bugs_deleted_at_idx ON public.bugs USING btree (deleted_at) WHERE (deleted_at IS NOT NULL);
+
+
+--
+-- Name: bugs_milestone_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bugs_milestone_id_idx ON public.bugs USING btree (milestone_id);
+
+
+--
+-- Name: bugs_priority_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bugs_priority_idx ON public.bugs USING btree (priority);
+
+
+--
+-- Name: bugs_project_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bugs_project_id_idx ON public.bugs USING btree (project_id);
+
+
+--
+-- Name: bugs_reopen_count_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bugs_reopen_count_idx ON public.bugs USING btree (reopen_count) WHERE (reopen_count > 0);
+
+
+--
+-- Name: bugs_severity_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX bugs_severity_idx ON public.bugs USING btree (severity);
+
+
+--
+-- Name: comments_bug_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX comments_bug_id_idx ON public.comments USING btree (bug_id);
+
+
+--
+-- Name: mentions_bug_id_user_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX mentions_bug_id_user_id_idx ON public.mentions USING btree (bug_id, user_id) WHERE (comment_id IS NULL);
+
+
+--
+-- Name: mentions_comment_id_user_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX mentions_comment_id_user_id_idx ON public.mentions USING btree (comment_id, user_id) WHERE (comment_id IS NOT NULL);
+
+
+--
+-- Name: mentions_user_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX mentions_user_id_idx ON public.mentions USING btree (user_id);
+
+
+--
+-- Name: work_logs_bug_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX work_logs_bug_id_idx ON public.work_logs USING btree (bug_id);
+
+
+--
+-- Name: work_logs_work_date_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX work_logs_work_date_idx ON public.work_logs USING btree (work_date);
+
+
+--
+-- Name: users users_release_votes; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE TRIGGER users_release_votes BEFORE DELETE ON public.users FOR EACH ROW EXECUTE FUNCTION public.release_user_votes();
+
+
+--
+-- Name: attachments attachments_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.attachments
+    ADD CONSTRAINT attachments_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: attachments attachments_uploaded_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.attachments
+    ADD CONSTRAINT attachments_uploaded_by_fkey FOREIGN KEY (uploaded_by) REFERENCES public.users(id);
+
+
+--
+-- Name: bug_events bug_events_actor_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_events
+    ADD CONSTRAINT bug_events_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES public.users(id);
+
+
+--
+-- Name: bug_events bug_events_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_events
+    ADD CONSTRAINT bug_events_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_field_values bug_field_values_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_field_values
+    ADD CONSTRAINT bug_field_values_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_field_values bug_field_values_field_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_field_values
+    ADD CONSTRAINT bug_field_values_field_id_fkey FOREIGN KEY (field_id) REFERENCES public.custom_fields(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_labels bug_labels_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_labels
+    ADD CONSTRAINT bug_labels_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_labels bug_labels_label_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_labels
+    ADD CONSTRAINT bug_labels_label_id_fkey FOREIGN KEY (label_id) REFERENCES public.labels(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_links bug_links_created_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_links
+    ADD CONSTRAINT bug_links_created_by_fkey FOREIGN KEY (created_by) REFERENCES public.users(id);
+
+
+--
+-- Name: bug_links bug_links_source_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_links
+    ADD CONSTRAINT bug_links_source_id_fkey FOREIGN KEY (source_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_links bug_links_target_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_links
+    ADD CONSTRAINT bug_links_target_id_fkey FOREIGN KEY (target_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_status_transitions bug_status_transitions_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_status_transitions
+    ADD CONSTRAINT bug_status_transitions_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_status_transitions bug_status_transitions_changed_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_status_transitions
+    ADD CONSTRAINT bug_status_transitions_changed_by_fkey FOREIGN KEY (changed_by) REFERENCES public.users(id);
+
+
+--
+-- Name: bug_templates bug_templates_project_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_templates
+    ADD CONSTRAINT bug_templates_project_id_fkey FOREIGN KEY (project_id) REFERENCES public.projects(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_votes bug_votes_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_votes
+    ADD CONSTRAINT bug_votes_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_votes bug_votes_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_votes
+    ADD CONSTRAINT bug_votes_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_watchers bug_watchers_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_watchers
+    ADD CONSTRAINT bug_watchers_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bug_watchers bug_watchers_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bug_watchers
+    ADD CONSTRAINT bug_watchers_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: bugs bugs_assignee_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bugs
+    ADD CONSTRAINT bugs_assignee_id_fkey FOREIGN KEY (assignee_id) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
+--
+-- Name: bugs bugs_component_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bugs
+    ADD CONSTRAINT bugs_component_id_fkey FOREIGN KEY (component_id) REFERENCES public.components(id) ON DELETE SET NULL;
+
+
+--
+-- Name: bugs bugs_merged_into_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bugs
+    ADD CONSTRAINT bugs_merged_into_id_fkey FOREIGN KEY (merged_into_id) REFERENCES public.bugs(id) ON DELETE SET NULL;
+
+
+--
+-- Name: bugs bugs_milestone_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bugs
+    ADD CONSTRAINT bugs_milestone_id_fkey FOREIGN KEY (milestone_id) REFERENCES public.milestones(id) ON DELETE SET NULL;
+
+
+--
+-- Name: bugs bugs_posted_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bugs
+    ADD CONSTRAINT bugs_posted_by_fkey FOREIGN KEY (posted_by) REFERENCES public.users(id);
+
+
+--
+-- Name: bugs bugs_project_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bugs
+    ADD CONSTRAINT bugs_project_id_fkey FOREIGN KEY (project_id) REFERENCES public.projects(id);
+
+
+--
+-- Name: comments comments_author_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.comments
+    ADD CONSTRAINT comments_author_id_fkey FOREIGN KEY (author_id) REFERENCES public.users(id);
+
+
+--
+-- Name: comments comments_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.comments
+    ADD CONSTRAINT comments_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: comments comments_parent_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.comments
+    ADD CONSTRAINT comments_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES public.comments(id) ON DELETE SET NULL;
+
+
+--
+-- Name: components components_owner_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.components
+    ADD CONSTRAINT components_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
+--
+-- Name: components components_project_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.components
+    ADD CONSTRAINT components_project_id_fkey FOREIGN KEY (project_id) REFERENCES public.projects(id) ON DELETE CASCADE;
+
+
+--
+-- Name: custom_fields custom_fields_project_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.custom_fields
+    ADD CONSTRAINT custom_fields_project_id_fkey FOREIGN KEY (project_id) REFERENCES public.projects(id) ON DELETE CASCADE;
+
+
+--
+-- Name: mentions mentions_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.mentions
+    ADD CONSTRAINT mentions_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: mentions mentions_comment_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.mentions
+    ADD CONSTRAINT mentions_comment_id_fkey FOREIGN KEY (comment_id) REFERENCES public.comments(id) ON DELETE CASCADE;
+
+
+--
+-- Name: mentions mentions_mentioned_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.mentions
+    ADD CONSTRAINT mentions_mentioned_by_fkey FOREIGN KEY (mentioned_by) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: mentions mentions_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.mentions
+    ADD CONSTRAINT mentions_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: milestones milestones_project_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.milestones
+    ADD CONSTRAINT milestones_project_id_fkey FOREIGN KEY (project_id) REFERENCES public.projects(id) ON DELETE CASCADE;
+
+
+--
+-- Name: projects projects_owner_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.projects
+    ADD CONSTRAINT projects_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES public.users(id);
+
+
+--
+-- Name: refresh_tokens refresh_tokens_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
 ALTER TABLE ONLY public.refresh_tokens
     ADD CONSTRAINT refresh_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
 
 
+--
+-- Name: work_logs work_logs_bug_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.work_logs
+    ADD CONSTRAINT work_logs_bug_id_fkey FOREIGN KEY (bug_id) REFERENCES public.bugs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: work_logs work_logs_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.work_logs
+    ADD CONSTRAINT work_logs_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
 --
 -- PostgreSQL database dump complete
 --
@@ -0,0 +1,49 @@
+-- name: CreateAttachment :one
+INSERT INTO attachments (id, bug_id, uploaded_by, filename, content_type, size_bytes, sha256, created_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    NOW()
+)
+RETURNING *;
+
+-- name: GetAttachmentsByBug :many
+SELECT * FROM attachments
+WHERE bug_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetAttachmentByID :one
+SELECT * FROM attachments
+WHERE id = $1;
+
+-- name: GetAttachmentByHash :one
+SELECT * FROM attachments
+WHERE bug_id = $1 AND sha256 = $2;
+
+-- name: MoveAttachments :execrows
+UPDATE attachments
+SET bug_id = sqlc.arg('to_bug_id')
+WHERE bug_id = sqlc.arg('from_bug_id')
+  AND sha256 NOT IN (
+    SELECT existing.sha256 FROM attachments AS existing
+    WHERE existing.bug_id = sqlc.arg('to_bug_id')
+  );
+
+-- name: GetPurgeableAttachmentHashes :many
+SELECT DISTINCT attachments.sha256 FROM attachments
+JOIN bugs ON bugs.id = attachments.bug_id
+WHERE bugs.deleted_at IS NOT NULL AND bugs.deleted_at < sqlc.arg('cutoff')::timestamp;
+
+-- name: GetUnreferencedAttachmentHashes :many
+SELECT hash::text FROM unnest(sqlc.arg('hashes')::text[]) AS hash
+WHERE NOT EXISTS (
+    SELECT 1 FROM attachments WHERE attachments.sha256 = hash
+);
+
+-- name: LockAttachmentHash :exec
+SELECT pg_advisory_xact_lock(hashtext(sqlc.arg('sha256')::text));
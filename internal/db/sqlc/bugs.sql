@@ -1,24 +1,79 @@
 -- name: CreateBug :one
-INSERT INTO bugs (id, title, description, posted_by, created_at, updated_at)
+INSERT INTO bugs (id, title, description, posted_by, severity, priority, project_id, number, key, assignee_id, component_id, due_at, confidential, created_at, updated_at)
 VALUES (
     gen_random_uuid(),
     $1,
     $2,
     $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
     NOW(),
     NOW()
-    
 )
 RETURNING *;
 
 
 -- name: GetAllBugs :many
 SELECT * FROM bugs
+WHERE deleted_at IS NULL
 ORDER BY created_at DESC;
 
+-- name: ListBugs :many
+SELECT * FROM bugs
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('severities')::text[] IS NULL OR severity = ANY(sqlc.narg('severities')::text[]))
+  AND (sqlc.narg('priorities')::text[] IS NULL OR priority = ANY(sqlc.narg('priorities')::text[]))
+  AND (sqlc.narg('labels')::text[] IS NULL OR id IN (
+    SELECT bug_labels.bug_id FROM bug_labels
+    JOIN labels ON labels.id = bug_labels.label_id
+    WHERE labels.name = ANY(sqlc.narg('labels')::text[])
+    GROUP BY bug_labels.bug_id
+    HAVING COUNT(DISTINCT labels.name) = cardinality(sqlc.narg('labels')::text[])
+  ))
+  AND (sqlc.narg('project_id')::uuid IS NULL OR project_id = sqlc.narg('project_id'))
+  AND (sqlc.narg('field_names')::text[] IS NULL OR id IN (
+    SELECT bug_field_values.bug_id FROM bug_field_values
+    JOIN custom_fields ON custom_fields.id = bug_field_values.field_id
+    JOIN unnest(sqlc.narg('field_names')::text[], sqlc.narg('field_values')::text[]) AS wanted (name, value)
+        ON wanted.name = custom_fields.name AND wanted.value = bug_field_values.value
+    GROUP BY bug_field_values.bug_id
+    HAVING COUNT(*) = cardinality(sqlc.narg('field_names')::text[])
+  ))
+  AND (sqlc.narg('sla')::text IS NULL OR id IN (
+    SELECT sla_bugs.id FROM bugs AS sla_bugs
+    LEFT JOIN sla_policies ON sla_policies.priority = sla_bugs.priority
+    CROSS JOIN LATERAL (
+        SELECT COALESCE(sla_bugs.due_at, sla_bugs.created_at + make_interval(hours => sla_policies.resolve_within_hours)) AS deadline
+    ) AS sla
+    WHERE sla_bugs.status NOT IN ('resolved', 'closed')
+      AND sla.deadline IS NOT NULL
+      AND CASE
+        WHEN NOW() >= sla.deadline THEN 'breached'
+        WHEN NOW() >= sla.deadline - (sla.deadline - sla_bugs.created_at) / 5 THEN 'at_risk'
+        ELSE 'ok'
+      END = sqlc.narg('sla')::text
+  ))
+  AND (NOT confidential OR sqlc.arg('see_confidential')::boolean OR posted_by = sqlc.narg('viewer_id') OR assignee_id = sqlc.narg('viewer_id'))
+ORDER BY
+    CASE WHEN sqlc.arg('sort')::text = 'priority' THEN priority END ASC,
+    CASE WHEN sqlc.arg('sort')::text = 'severity' THEN array_position(ARRAY['blocker', 'critical', 'major', 'minor', 'trivial'], severity) END ASC,
+    CASE WHEN sqlc.arg('sort')::text = 'votes' THEN vote_count END DESC,
+    created_at DESC;
+
 -- name: GetBugsByID :one
 SELECT * FROM bugs
-WHERE Id = $1;
+WHERE Id = $1 AND deleted_at IS NULL;
+
+-- name: GetBugByKey :one
+SELECT * FROM bugs
+WHERE key = $1 AND deleted_at IS NULL;
 
 
 -- name: UpdateBugByID :exec
@@ -26,10 +81,118 @@ UPDATE bugs
 SET 
     title = COALESCE(sqlc.narg('title'), title),
     description = COALESCE(sqlc.narg('description'), description),
+    severity = COALESCE(sqlc.narg('severity'), severity),
+    priority = COALESCE(sqlc.narg('priority'), priority),
     updated_at = Now()
 WHERE id = $1;
 
--- name: DeleteBugByID :exec
+-- name: SoftDeleteBug :one
+UPDATE bugs
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING deleted_at;
+
+-- name: GetDeletedBugByID :one
+SELECT * FROM bugs
+WHERE id = $1 AND deleted_at IS NOT NULL
+FOR UPDATE;
+
+-- name: GetDeletedBugs :many
+SELECT * FROM bugs
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: RestoreBug :one
+UPDATE bugs
+SET
+    deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeDeletedBugs :execrows
 DELETE FROM bugs
+WHERE deleted_at IS NOT NULL AND deleted_at < sqlc.arg('cutoff')::timestamp;
+
+
+
+-- name: UpdateBugStatus :execrows
+UPDATE bugs
+SET
+    status = sqlc.arg('to_status'),
+    resolution = sqlc.narg('resolution'),
+    reopen_count = CASE WHEN sqlc.arg('to_status') = 'reopened' THEN reopen_count + 1 ELSE reopen_count END,
+    last_reopened_at = CASE WHEN sqlc.arg('to_status') = 'reopened' THEN NOW() ELSE last_reopened_at END,
+    updated_at = NOW()
+WHERE id = $1 AND status = sqlc.arg('from_status');
+
+
+-- name: SetBugAssignee :exec
+UPDATE bugs
+SET
+    assignee_id = sqlc.narg('assignee_id'),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetBugsByAssignee :many
+SELECT * FROM bugs
+WHERE assignee_id = sqlc.arg('assignee_id')::uuid AND deleted_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: SetBugMilestone :exec
+UPDATE bugs
+SET
+    milestone_id = sqlc.narg('milestone_id'),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetBugComponent :exec
+UPDATE bugs
+SET
+    component_id = sqlc.narg('component_id'),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetBugDueAt :exec
+UPDATE bugs
+SET
+    due_at = sqlc.narg('due_at'),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetBugEstimate :exec
+UPDATE bugs
+SET
+    estimate_minutes = sqlc.narg('estimate_minutes'),
+    updated_at = NOW()
 WHERE id = $1;
 
+-- name: GetMostReopenedBugs :many
+SELECT * FROM bugs
+WHERE deleted_at IS NULL
+  AND reopen_count > 0
+  AND (sqlc.narg('project_id')::uuid IS NULL OR project_id = sqlc.narg('project_id'))
+  AND (NOT confidential OR sqlc.arg('see_confidential')::boolean OR posted_by = sqlc.narg('viewer_id') OR assignee_id = sqlc.narg('viewer_id'))
+ORDER BY reopen_count DESC, last_reopened_at DESC
+LIMIT sqlc.arg('max_results');
+
+-- name: SetBugMergedInto :exec
+UPDATE bugs
+SET
+    merged_into_id = sqlc.narg('merged_into_id'),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetBugResolution :execrows
+UPDATE bugs
+SET
+    resolution = sqlc.narg('resolution'),
+    updated_at = NOW()
+WHERE id = $1 AND status = sqlc.arg('status');
+
+-- name: SetBugConfidential :exec
+UPDATE bugs
+SET
+    confidential = $2,
+    updated_at = NOW()
+WHERE id = $1;
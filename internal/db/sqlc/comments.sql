@@ -0,0 +1,39 @@
+-- name: CreateComment :one
+INSERT INTO comments (id, bug_id, author_id, parent_id, body, created_at, updated_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW(),
+    NOW()
+)
+RETURNING *;
+
+-- name: GetCommentsByBug :many
+SELECT * FROM comments
+WHERE bug_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetCommentByID :one
+SELECT * FROM comments
+WHERE id = $1;
+
+-- name: UpdateComment :one
+UPDATE comments
+SET
+    body = $2,
+    updated_at = NOW(),
+    edited_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteComment :exec
+DELETE FROM comments
+WHERE id = $1;
+
+-- name: MoveComments :execrows
+UPDATE comments
+SET bug_id = sqlc.arg('to_bug_id')
+WHERE bug_id = sqlc.arg('from_bug_id');
@@ -0,0 +1,35 @@
+-- name: CreateComponent :one
+INSERT INTO components (id, project_id, name, description, owner_id, created_at, updated_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW(),
+    NOW()
+)
+RETURNING *;
+
+-- name: GetComponentByID :one
+SELECT * FROM components
+WHERE id = $1;
+
+-- name: GetComponentByName :one
+SELECT * FROM components
+WHERE project_id = $1 AND name = $2;
+
+-- name: GetComponentsByProject :many
+SELECT * FROM components
+WHERE project_id = $1
+ORDER BY name ASC;
+
+-- name: UpdateComponent :one
+UPDATE components
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    description = COALESCE(sqlc.narg('description'), description),
+    owner_id = COALESCE(sqlc.narg('owner_id'), owner_id),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
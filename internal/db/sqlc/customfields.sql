@@ -0,0 +1,41 @@
+-- name: CreateCustomField :one
+INSERT INTO custom_fields (id, project_id, name, field_type, options, created_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW()
+)
+RETURNING *;
+
+-- name: GetCustomFieldsByName :many
+SELECT * FROM custom_fields
+WHERE name = ANY(sqlc.arg('names')::text[])
+  AND (sqlc.narg('project_id')::uuid IS NULL OR project_id = sqlc.narg('project_id'))
+ORDER BY name ASC;
+
+-- name: GetCustomFieldsByProject :many
+SELECT * FROM custom_fields
+WHERE project_id = $1
+ORDER BY name ASC;
+
+-- name: DeleteCustomField :execrows
+DELETE FROM custom_fields
+WHERE id = $1;
+
+-- name: GetBugFieldValues :many
+SELECT custom_fields.id, custom_fields.name, custom_fields.field_type, bug_field_values.value FROM bug_field_values
+JOIN custom_fields ON custom_fields.id = bug_field_values.field_id
+WHERE bug_field_values.bug_id = $1
+ORDER BY custom_fields.name ASC;
+
+-- name: SetBugFieldValue :exec
+INSERT INTO bug_field_values (bug_id, field_id, value)
+VALUES ($1, $2, $3)
+ON CONFLICT (bug_id, field_id) DO UPDATE SET value = EXCLUDED.value;
+
+-- name: DeleteBugFieldValue :exec
+DELETE FROM bug_field_values
+WHERE bug_id = $1 AND field_id = $2;
@@ -0,0 +1,16 @@
+-- name: CreateBugEvent :exec
+INSERT INTO bug_events (id, bug_id, actor_id, field, old_value, new_value, created_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW()
+);
+
+-- name: GetBugEvents :many
+SELECT * FROM bug_events
+WHERE bug_id = $1
+ORDER BY created_at ASC;
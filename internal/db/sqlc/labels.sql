@@ -0,0 +1,58 @@
+-- name: CreateLabel :one
+INSERT INTO labels (id, name, color, description, created_at, updated_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    NOW(),
+    NOW()
+)
+RETURNING *;
+
+-- name: GetAllLabels :many
+SELECT * FROM labels
+ORDER BY name ASC;
+
+-- name: GetLabelByID :one
+SELECT * FROM labels
+WHERE id = $1;
+
+-- name: GetLabelByName :one
+SELECT * FROM labels
+WHERE name = $1;
+
+-- name: UpdateLabel :one
+UPDATE labels
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    color = COALESCE(sqlc.narg('color'), color),
+    description = COALESCE(sqlc.narg('description'), description),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteLabel :execrows
+DELETE FROM labels
+WHERE id = $1;
+
+-- name: AddLabelToBug :execrows
+INSERT INTO bug_labels (bug_id, label_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveLabelFromBug :execrows
+DELETE FROM bug_labels
+WHERE bug_id = $1 AND label_id = $2;
+
+-- name: GetLabelsByBug :many
+SELECT labels.* FROM labels
+JOIN bug_labels ON bug_labels.label_id = labels.id
+WHERE bug_labels.bug_id = $1
+ORDER BY labels.name ASC;
+
+-- name: AddLabelsToBugByName :exec
+INSERT INTO bug_labels (bug_id, label_id)
+SELECT sqlc.arg('bug_id')::uuid, labels.id FROM labels
+WHERE labels.name = ANY(sqlc.arg('names')::text[])
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,54 @@
+-- name: CreateBugLink :one
+INSERT INTO bug_links (id, source_id, target_id, link_type, created_by, created_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW()
+)
+RETURNING *;
+
+-- name: GetBugLinkByID :one
+SELECT * FROM bug_links
+WHERE id = $1;
+
+-- name: GetBugLinks :many
+SELECT bug_links.id, bug_links.source_id, bug_links.target_id, bug_links.link_type, bug_links.created_by, bug_links.created_at,
+    bugs.key AS linked_key,
+    bugs.title AS linked_title,
+    bugs.status AS linked_status
+FROM bug_links
+JOIN bugs ON bugs.id = CASE WHEN bug_links.source_id = sqlc.arg('bug_id') THEN bug_links.target_id ELSE bug_links.source_id END
+WHERE (bug_links.source_id = sqlc.arg('bug_id') OR bug_links.target_id = sqlc.arg('bug_id'))
+  AND bugs.deleted_at IS NULL
+  AND (NOT bugs.confidential OR sqlc.arg('see_confidential')::boolean OR bugs.posted_by = sqlc.narg('viewer_id') OR bugs.assignee_id = sqlc.narg('viewer_id'))
+ORDER BY bug_links.created_at ASC;
+
+-- name: DeleteBugLink :exec
+DELETE FROM bug_links
+WHERE id = $1;
+
+-- name: DeleteDuplicateOfLink :exec
+DELETE FROM bug_links
+WHERE source_id = $1 AND link_type = 'duplicate-of';
+
+-- name: LinkPathExists :one
+WITH RECURSIVE reachable AS (
+    SELECT bug_links.target_id FROM bug_links
+    WHERE bug_links.source_id = sqlc.arg('from_id') AND bug_links.link_type = sqlc.arg('link_type')
+    UNION
+    SELECT bug_links.target_id FROM bug_links
+    JOIN reachable ON bug_links.source_id = reachable.target_id
+    WHERE bug_links.link_type = sqlc.arg('link_type')
+)
+SELECT EXISTS (
+    SELECT 1 FROM reachable WHERE target_id = sqlc.arg('to_id')
+);
+
+-- name: GetCanonicalBug :one
+SELECT bugs.* FROM bugs
+JOIN bug_links ON bug_links.target_id = bugs.id
+WHERE bug_links.source_id = $1 AND bug_links.link_type = 'duplicate-of'
+  AND bugs.deleted_at IS NULL;
@@ -0,0 +1,31 @@
+-- name: CreateMention :exec
+INSERT INTO mentions (id, bug_id, comment_id, user_id, mentioned_by, created_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW()
+)
+ON CONFLICT DO NOTHING;
+
+-- name: GetMentionCandidates :many
+SELECT id, email, role FROM users
+WHERE lower(email) = ANY(sqlc.arg('emails')::text[])
+   OR lower(split_part(email, '@', 1)) = ANY(sqlc.arg('handles')::text[]);
+
+-- name: GetMentionsByUser :many
+SELECT mentions.id, mentions.bug_id, mentions.comment_id, mentions.mentioned_by, mentions.created_at,
+    bugs.key AS bug_key,
+    bugs.title AS bug_title
+FROM mentions
+JOIN bugs ON bugs.id = mentions.bug_id
+WHERE mentions.user_id = sqlc.arg('user_id') AND bugs.deleted_at IS NULL
+  AND (NOT bugs.confidential OR sqlc.arg('see_confidential')::boolean OR bugs.posted_by = sqlc.arg('user_id') OR bugs.assignee_id = sqlc.arg('user_id'))
+ORDER BY mentions.created_at DESC;
+
+-- name: MoveCommentMentions :exec
+UPDATE mentions
+SET bug_id = sqlc.arg('to_bug_id')
+WHERE bug_id = sqlc.arg('from_bug_id') AND comment_id IS NOT NULL;
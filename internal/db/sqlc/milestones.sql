@@ -0,0 +1,37 @@
+-- name: CreateMilestone :one
+INSERT INTO milestones (id, project_id, name, due_date, created_at, updated_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    NOW(),
+    NOW()
+)
+RETURNING *;
+
+-- name: GetMilestoneByID :one
+SELECT * FROM milestones
+WHERE id = $1;
+
+-- name: GetMilestonesByProject :many
+SELECT * FROM milestones
+WHERE project_id = $1
+ORDER BY due_date ASC NULLS LAST, name ASC;
+
+-- name: UpdateMilestone :one
+UPDATE milestones
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    due_date = COALESCE(sqlc.narg('due_date')::date, due_date),
+    state = COALESCE(sqlc.narg('state'), state),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetMilestoneProgress :one
+SELECT
+    COUNT(*) FILTER (WHERE status NOT IN ('resolved', 'closed')) AS open_count,
+    COUNT(*) FILTER (WHERE status IN ('resolved', 'closed')) AS closed_count
+FROM bugs
+WHERE milestone_id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,41 @@
+-- name: CreateProject :one
+INSERT INTO projects (id, key, name, description, owner_id, created_at, updated_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW(),
+    NOW()
+)
+RETURNING *;
+
+-- name: GetAllProjects :many
+SELECT * FROM projects
+ORDER BY key ASC;
+
+-- name: GetProjectByKey :one
+SELECT * FROM projects
+WHERE key = $1;
+
+-- name: GetProjectByID :one
+SELECT * FROM projects
+WHERE id = $1;
+
+-- name: UpdateProject :one
+UPDATE projects
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    description = COALESCE(sqlc.narg('description'), description),
+    owner_id = COALESCE(sqlc.narg('owner_id'), owner_id),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+
+-- name: AllocateBugNumber :one
+UPDATE projects
+SET last_bug_number = last_bug_number + 1
+WHERE id = $1
+RETURNING last_bug_number;
@@ -0,0 +1,20 @@
+-- name: GetSlaPolicies :many
+SELECT * FROM sla_policies
+ORDER BY priority ASC;
+
+-- name: GetSlaPolicy :one
+SELECT * FROM sla_policies
+WHERE priority = $1;
+
+-- name: UpsertSlaPolicy :one
+INSERT INTO sla_policies (priority, resolve_within_hours, updated_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (priority) DO UPDATE
+SET
+    resolve_within_hours = EXCLUDED.resolve_within_hours,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteSlaPolicy :execrows
+DELETE FROM sla_policies
+WHERE priority = $1;
@@ -0,0 +1,37 @@
+-- name: CreateBugTemplate :one
+INSERT INTO bug_templates (id, project_id, name, description, labels, required_fields, created_at, updated_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW(),
+    NOW()
+)
+RETURNING *;
+
+-- name: GetBugTemplateByID :one
+SELECT * FROM bug_templates
+WHERE id = $1;
+
+-- name: GetBugTemplatesByProject :many
+SELECT * FROM bug_templates
+WHERE project_id = $1
+ORDER BY name ASC;
+
+-- name: UpdateBugTemplate :one
+UPDATE bug_templates
+SET
+    name = COALESCE(sqlc.narg('name'), name),
+    description = COALESCE(sqlc.narg('description'), description),
+    labels = COALESCE(sqlc.narg('labels')::text[], labels),
+    required_fields = COALESCE(sqlc.narg('required_fields')::text[], required_fields),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBugTemplate :execrows
+DELETE FROM bug_templates
+WHERE id = $1;
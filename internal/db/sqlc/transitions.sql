@@ -0,0 +1,16 @@
+-- name: CreateBugStatusTransition :one
+INSERT INTO bug_status_transitions (id, bug_id, from_status, to_status, changed_by, changed_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    NOW()
+)
+RETURNING *;
+
+-- name: GetBugStatusTransitions :many
+SELECT * FROM bug_status_transitions
+WHERE bug_id = $1
+ORDER BY changed_at ASC;
@@ -23,4 +23,10 @@ SET
 WHERE id = $3;
 
 -- name: GetRoleByID :one
-SELECT role FROM users WHERE id = $1;
\ No newline at end of file
+SELECT role FROM users WHERE id = $1;
+
+-- name: GetUserByID :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: SetUserRole :execrows
+UPDATE users SET role = $2, updated_at = NOW() WHERE id = $1;
@@ -0,0 +1,30 @@
+-- name: AddBugVote :execrows
+INSERT INTO bug_votes (bug_id, user_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveBugVote :execrows
+DELETE FROM bug_votes
+WHERE bug_id = $1 AND user_id = $2;
+
+-- name: AdjustBugVoteCount :one
+UPDATE bugs
+SET vote_count = vote_count + sqlc.arg('delta')
+WHERE id = $1
+RETURNING vote_count;
+
+-- name: MoveBugVotes :execrows
+WITH moved AS (
+    DELETE FROM bug_votes
+    WHERE bug_id = sqlc.arg('from_bug_id')
+    RETURNING user_id, created_at
+)
+INSERT INTO bug_votes (bug_id, user_id, created_at)
+SELECT sqlc.arg('to_bug_id')::uuid, moved.user_id, moved.created_at FROM moved
+ON CONFLICT DO NOTHING;
+
+-- name: RecountBugVotes :one
+UPDATE bugs
+SET vote_count = (SELECT COUNT(*) FROM bug_votes WHERE bug_votes.bug_id = bugs.id)
+WHERE id = $1
+RETURNING vote_count;
@@ -0,0 +1,31 @@
+-- name: AddBugWatcher :exec
+INSERT INTO bug_watchers (bug_id, user_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveBugWatcher :execrows
+DELETE FROM bug_watchers
+WHERE bug_id = $1 AND user_id = $2;
+
+-- name: GetBugWatchers :many
+SELECT users.id, users.email, bug_watchers.created_at FROM bug_watchers
+JOIN users ON users.id = bug_watchers.user_id
+WHERE bug_watchers.bug_id = $1
+ORDER BY bug_watchers.created_at ASC;
+
+-- name: GetWatchedBugs :many
+SELECT bugs.* FROM bugs
+JOIN bug_watchers ON bug_watchers.bug_id = bugs.id
+WHERE bug_watchers.user_id = sqlc.arg('user_id') AND bugs.deleted_at IS NULL
+  AND (NOT bugs.confidential OR sqlc.arg('see_confidential')::boolean OR bugs.posted_by = sqlc.arg('user_id') OR bugs.assignee_id = sqlc.arg('user_id'))
+ORDER BY bugs.updated_at DESC;
+
+-- name: MoveBugWatchers :execrows
+WITH moved AS (
+    DELETE FROM bug_watchers
+    WHERE bug_id = sqlc.arg('from_bug_id')
+    RETURNING user_id, created_at
+)
+INSERT INTO bug_watchers (bug_id, user_id, created_at)
+SELECT sqlc.arg('to_bug_id')::uuid, moved.user_id, moved.created_at FROM moved
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,49 @@
+-- name: CreateWorkLog :one
+INSERT INTO work_logs (id, bug_id, user_id, minutes, note, work_date, created_at, updated_at)
+VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    NOW(),
+    NOW()
+)
+RETURNING *;
+
+-- name: GetWorkLogByID :one
+SELECT * FROM work_logs
+WHERE id = $1;
+
+-- name: GetWorkLogsByBug :many
+SELECT * FROM work_logs
+WHERE bug_id = $1
+ORDER BY work_date DESC, created_at DESC;
+
+-- name: UpdateWorkLog :one
+UPDATE work_logs
+SET
+    minutes = COALESCE(sqlc.narg('minutes'), minutes),
+    note = COALESCE(sqlc.narg('note'), note),
+    work_date = COALESCE(sqlc.narg('work_date'), work_date),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWorkLog :execrows
+DELETE FROM work_logs
+WHERE id = $1;
+
+-- name: GetWorkLogReport :many
+SELECT work_logs.user_id, users.email,
+    date_trunc('week', work_logs.work_date)::date AS week,
+    SUM(work_logs.minutes)::bigint AS minutes
+FROM work_logs
+JOIN users ON users.id = work_logs.user_id
+JOIN bugs ON bugs.id = work_logs.bug_id
+WHERE work_logs.work_date BETWEEN sqlc.arg('from_date') AND sqlc.arg('to_date')
+  AND bugs.deleted_at IS NULL
+  AND (sqlc.narg('project_id')::uuid IS NULL OR bugs.project_id = sqlc.narg('project_id'))
+GROUP BY work_logs.user_id, users.email, week
+ORDER BY week ASC, users.email ASC;
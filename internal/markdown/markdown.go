@@ -0,0 +1,630 @@
+// Package markdown renders the Markdown used in bug reports to HTML.
+//
+// It supports the CommonMark basics plus the GitHub extensions people paste into
+// bug reports: fenced code blocks, tables, task lists and strikethrough. The output
+// is safe to embed in a page as is. Every piece of source text is escaped, raw HTML
+// in the source is shown as text rather than passed through, the renderer only
+// emits a fixed set of tags and attributes, and links and images are dropped unless
+// they point at http, https, mailto or a relative URL.
+package markdown
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	rulePattern      = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fencePattern     = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*)$")
+	quotePattern     = regexp.MustCompile(`^ {0,3}> ?`)
+	listItemPattern  = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])(?:( +)(.*))?$`)
+	tableDelimiter   = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	taskPattern      = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+|$)`)
+	languagePattern  = regexp.MustCompile(`^[A-Za-z0-9_+#.-]+$`)
+	autolinkPattern  = regexp.MustCompile(`^<((?:https?://|mailto:)[^\s<>]+)>`)
+	htmlReplacements = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#39;")
+)
+
+// Render converts Markdown source to sanitized HTML.
+func Render(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\r", "\n")
+	source = strings.ReplaceAll(source, "\x00", "�")
+	var lines []string
+	for _, line := range strings.Split(source, "\n") {
+		lines = append(lines, expandTabs(line))
+	}
+	return strings.TrimSuffix(renderBlocks(lines, false), "\n")
+}
+
+func escape(s string) string {
+	return htmlReplacements.Replace(s)
+}
+
+func expandTabs(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range line {
+		if r == '\t' {
+			n := 4 - col%4
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		b.WriteRune(r)
+		col++
+	}
+	return b.String()
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// renderBlocks renders a sequence of lines as block elements. Paragraphs of a tight
+// list item are written without <p> so the item reads as one line.
+func renderBlocks(lines []string, tight bool) string {
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case fencePattern.MatchString(line):
+			i = renderFence(&b, lines, i)
+		case headingPattern.MatchString(line):
+			m := headingPattern.FindStringSubmatch(line)
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", len(m[1]), renderInline(m[2]), len(m[1]))
+			i++
+		case rulePattern.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+		case quotePattern.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && quotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quotePattern.ReplaceAllString(lines[i], ""))
+			}
+			b.WriteString("<blockquote>\n")
+			b.WriteString(renderBlocks(quoted, false))
+			b.WriteString("</blockquote>\n")
+		case isTableStart(lines, i):
+			i = renderTable(&b, lines, i)
+		case listItemPattern.MatchString(line):
+			i = renderList(&b, lines, i)
+		case indentOf(line) >= 4:
+			i = renderIndentedCode(&b, lines, i)
+		default:
+			i = renderParagraph(&b, lines, i, tight)
+		}
+	}
+	return b.String()
+}
+
+func renderFence(b *strings.Builder, lines []string, i int) int {
+	m := fencePattern.FindStringSubmatch(lines[i])
+	indent, fence := len(m[1]), m[2]
+	info := strings.Fields(m[3])
+	var code []string
+	i++
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if indentOf(lines[i]) < 4 && strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		line := lines[i]
+		for n := 0; n < indent && strings.HasPrefix(line, " "); n++ {
+			line = line[1:]
+		}
+		code = append(code, line)
+	}
+	b.WriteString("<pre><code")
+	if len(info) > 0 && languagePattern.MatchString(info[0]) {
+		fmt.Fprintf(b, ` class="language-%s"`, escape(info[0]))
+	}
+	b.WriteString(">")
+	for _, line := range code {
+		b.WriteString(escape(line))
+		b.WriteString("\n")
+	}
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+func renderIndentedCode(b *strings.Builder, lines []string, i int) int {
+	var code []string
+	for ; i < len(lines) && (indentOf(lines[i]) >= 4 || isBlank(lines[i])); i++ {
+		if isBlank(lines[i]) {
+			code = append(code, "")
+			continue
+		}
+		code = append(code, lines[i][4:])
+	}
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+	b.WriteString("<pre><code>")
+	for _, line := range code {
+		b.WriteString(escape(line))
+		b.WriteString("\n")
+	}
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+// startsBlock reports whether line interrupts a paragraph.
+func startsBlock(line string) bool {
+	if fencePattern.MatchString(line) || headingPattern.MatchString(line) || rulePattern.MatchString(line) || quotePattern.MatchString(line) {
+		return true
+	}
+	m := listItemPattern.FindStringSubmatch(line)
+	// Only lists starting at 1 interrupt a paragraph, so a sentence ending in a year
+	// followed by a period on the next line stays text.
+	return m != nil && m[4] != "" && (!isOrderedMarker(m[2]) || strings.TrimRight(m[2], ".)") == "1")
+}
+
+func renderParagraph(b *strings.Builder, lines []string, i int, tight bool) int {
+	var text []string
+	for ; i < len(lines) && !isBlank(lines[i]); i++ {
+		if len(text) > 0 && (startsBlock(lines[i]) || isTableStart(lines, i)) {
+			break
+		}
+		text = append(text, lines[i])
+	}
+	var inline strings.Builder
+	for n, line := range text {
+		line = strings.TrimLeft(line, " ")
+		if n == len(text)-1 {
+			inline.WriteString(renderInline(strings.TrimRight(line, " ")))
+			break
+		}
+		hardBreak := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, `\`)
+		line = strings.TrimRight(line, " ")
+		if hardBreak {
+			line = strings.TrimSuffix(line, `\`)
+		}
+		inline.WriteString(renderInline(line))
+		if hardBreak {
+			inline.WriteString("<br>")
+		}
+		inline.WriteString("\n")
+	}
+	if tight {
+		b.WriteString(inline.String())
+		b.WriteString("\n")
+	} else {
+		fmt.Fprintf(b, "<p>%s</p>\n", inline.String())
+	}
+	return i
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	inCode := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '`':
+			inCode = !inCode
+			cell.WriteByte('`')
+		case line[i] == '|' && !inCode:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func isTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") || !tableDelimiter.MatchString(lines[i+1]) {
+		return false
+	}
+	if !strings.Contains(lines[i+1], "|") && len(splitTableRow(lines[i])) < 2 {
+		return false
+	}
+	return len(splitTableRow(lines[i])) == len(splitTableRow(lines[i+1]))
+}
+
+func renderTable(b *strings.Builder, lines []string, i int) int {
+	header := splitTableRow(lines[i])
+	var aligns []string
+	for _, d := range splitTableRow(lines[i+1]) {
+		switch left, right := strings.HasPrefix(d, ":"), strings.HasSuffix(d, ":"); {
+		case left && right:
+			aligns = append(aligns, "center")
+		case right:
+			aligns = append(aligns, "right")
+		case left:
+			aligns = append(aligns, "left")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+	writeRow := func(cells []string, tag string) {
+		b.WriteString("<tr>")
+		for n, align := range aligns {
+			cell := ""
+			if n < len(cells) {
+				cell = cells[n]
+			}
+			if align != "" {
+				fmt.Fprintf(b, `<%s align="%s">`, tag, align)
+			} else {
+				fmt.Fprintf(b, "<%s>", tag)
+			}
+			fmt.Fprintf(b, "%s</%s>", renderInline(cell), tag)
+		}
+		b.WriteString("</tr>\n")
+	}
+
+	b.WriteString("<table>\n<thead>\n")
+	writeRow(header, "th")
+	b.WriteString("</thead>\n")
+	i += 2
+	if i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|") {
+		b.WriteString("<tbody>\n")
+		for ; i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|") && !startsBlock(lines[i]); i++ {
+			writeRow(splitTableRow(lines[i]), "td")
+		}
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+	return i
+}
+
+func isOrderedMarker(marker string) bool {
+	return marker[0] >= '0' && marker[0] <= '9'
+}
+
+// sameList reports whether the list item match m continues a list of the given kind.
+func sameList(m []string, ordered bool, delimiter string) bool {
+	return m != nil && isOrderedMarker(m[2]) == ordered && strings.HasSuffix(m[2], delimiter)
+}
+
+type listItem struct {
+	lines []string
+	// blankAfter is set when a blank line separates the item from the next one.
+	blankAfter bool
+}
+
+// renderList renders the list starting at lines[i]. An item runs on as long as lines
+// are indented past its marker; a marker of another kind starts a new list.
+func renderList(b *strings.Builder, lines []string, i int) int {
+	opener := listItemPattern.FindStringSubmatch(lines[i])
+	ordered := isOrderedMarker(opener[2])
+	delimiter := opener[2][len(opener[2])-1:]
+
+	var items []listItem
+	loose := false
+	for i < len(lines) {
+		m := listItemPattern.FindStringSubmatch(lines[i])
+		if !sameList(m, ordered, delimiter) {
+			break
+		}
+		contentIndent := len(m[1]) + len(m[2]) + len(m[3])
+		text := m[4]
+		if len(m[3]) > 4 || m[4] == "" {
+			// Content indented like code or an empty first line: the item body starts
+			// one space after the marker.
+			contentIndent = len(m[1]) + len(m[2]) + 1
+			if m[4] != "" {
+				text = strings.Repeat(" ", len(m[3])-1) + m[4]
+			}
+		}
+		item := listItem{lines: []string{text}}
+		i++
+		for i < len(lines) {
+			if isBlank(lines[i]) {
+				j := i
+				for j < len(lines) && isBlank(lines[j]) {
+					j++
+				}
+				if j < len(lines) && indentOf(lines[j]) >= contentIndent {
+					for ; i < j; i++ {
+						item.lines = append(item.lines, "")
+					}
+					loose = true
+					continue
+				}
+				item.blankAfter = true
+				i = j
+				break
+			}
+			if indentOf(lines[i]) >= contentIndent {
+				item.lines = append(item.lines, lines[i][contentIndent:])
+				i++
+				continue
+			}
+			if listItemPattern.MatchString(lines[i]) || startsBlock(lines[i]) {
+				break
+			}
+			// Lazy continuation of the item's paragraph.
+			item.lines = append(item.lines, strings.TrimLeft(lines[i], " "))
+			i++
+		}
+		items = append(items, item)
+		if item.blankAfter {
+			if i >= len(lines) || !sameList(listItemPattern.FindStringSubmatch(lines[i]), ordered, delimiter) {
+				break
+			}
+			loose = true
+		}
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if start, _ := strconv.Atoi(strings.TrimRight(opener[2], ".)")); start != 1 {
+			fmt.Fprintf(b, "<ol start=\"%d\">\n", start)
+		} else {
+			b.WriteString("<ol>\n")
+		}
+	} else {
+		b.WriteString("<ul>\n")
+	}
+	for _, item := range items {
+		body := item.lines
+		task := ""
+		if m := taskPattern.FindStringSubmatch(body[0]); m != nil {
+			task = `<input type="checkbox" disabled>`
+			if m[1] != " " {
+				task = `<input type="checkbox" checked disabled>`
+			}
+			body = append([]string{body[0][len(m[0]):]}, body[1:]...)
+		}
+		if task != "" {
+			b.WriteString(`<li class="task-list-item">`)
+			b.WriteString(task)
+			b.WriteString(" ")
+		} else {
+			b.WriteString("<li>")
+		}
+		b.WriteString(strings.TrimSuffix(renderBlocks(body, !loose), "\n"))
+		b.WriteString("</li>\n")
+	}
+	fmt.Fprintf(b, "</%s>\n", tag)
+	return i
+}
+
+// safeURL reports whether a link target may be emitted: http, https and mailto URLs
+// and relative references only, so javascript: and data: URLs never reach a page.
+func safeURL(raw string) bool {
+	if raw == "" || strings.ContainsAny(raw, " \t\n\x00") {
+		return false
+	}
+	for _, r := range raw {
+		if r < 0x20 || r == 0x7f {
+			return false
+		}
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return true
+	case "":
+		// A scheme hidden behind characters url.Parse does not treat as one, such
+		// as "java\nscript:", was rejected above; anything else before a colon in
+		// the first segment is refused as well.
+		first, _, _ := strings.Cut(raw, "/")
+		return !strings.Contains(first, ":")
+	}
+	return false
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// closingBracket returns the index of the ] matching the [ at s[i], or -1.
+func closingBracket(s string, i int) int {
+	depth := 0
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				i += end + 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// linkTarget parses "(url)" or "(url "title")" at s[i] and returns the url and the
+// index after the closing parenthesis. Parentheses inside the url must balance.
+func linkTarget(s string, i int) (string, int, bool) {
+	if i >= len(s) || s[i] != '(' {
+		return "", 0, false
+	}
+	depth := 0
+	for end := i; end < len(s); end++ {
+		switch s[end] {
+		case '\\':
+			end++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				target, _, _ := strings.Cut(strings.TrimSpace(s[i+1:end]), " ")
+				target = strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+				return target, end + 1, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+// closingDelimiter finds the delimiter run that closes an emphasis opened at s[0:],
+// skipping code spans. It returns -1 when there is none.
+func closingDelimiter(s, delim string) int {
+	for i := 1; i+len(delim) <= len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case s[i] == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				i += end + 1
+			}
+		case strings.HasPrefix(s[i:], delim) && !isSpace(s[i-1]):
+			after := i + len(delim)
+			// For a single delimiter do not stop inside a longer run such as **.
+			if len(delim) == 1 && after < len(s) && s[after] == delim[0] {
+				i++
+				continue
+			}
+			if delim[0] == '_' && after < len(s) && isAlnum(s[after]) {
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func renderInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			b.WriteString(escape(s[i+1 : i+2]))
+			i += 2
+			continue
+		case c == '`':
+			n := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+			run := s[i : i+n]
+			if end := strings.Index(s[i+n:], run); end >= 0 {
+				code := strings.ReplaceAll(s[i+n:i+n+end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				b.WriteString("<code>" + escape(code) + "</code>")
+				i += n + end + n
+				continue
+			}
+			b.WriteString(run)
+			i += n
+			continue
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if end := closingBracket(s, i+1); end >= 0 {
+				if target, next, ok := linkTarget(s, end+1); ok {
+					alt := s[i+2 : end]
+					if safeURL(target) {
+						fmt.Fprintf(&b, `<img src="%s" alt="%s">`, escape(target), escape(alt))
+					} else {
+						b.WriteString(escape(alt))
+					}
+					i = next
+					continue
+				}
+			}
+		case c == '[':
+			if end := closingBracket(s, i); end >= 0 {
+				if target, next, ok := linkTarget(s, end+1); ok {
+					text := renderInline(s[i+1 : end])
+					if safeURL(target) {
+						fmt.Fprintf(&b, `<a href="%s" rel="nofollow noopener noreferrer">%s</a>`, escape(target), text)
+					} else {
+						b.WriteString(text)
+					}
+					i = next
+					continue
+				}
+			}
+		case c == '<':
+			if m := autolinkPattern.FindStringSubmatch(s[i:]); m != nil && safeURL(m[1]) {
+				fmt.Fprintf(&b, `<a href="%s" rel="nofollow noopener noreferrer">%s</a>`, escape(m[1]), escape(m[1]))
+				i += len(m[0])
+				continue
+			}
+		case c == '*' || c == '_' || c == '~':
+			if html, next, ok := renderEmphasis(s, i); ok {
+				b.WriteString(html)
+				i = next
+				continue
+			}
+		}
+		b.WriteString(escape(s[i : i+1]))
+		i++
+	}
+	return b.String()
+}
+
+// renderEmphasis renders *em*, **strong** and ~~strikethrough~~ (and the _ forms)
+// opened at s[i].
+func renderEmphasis(s string, i int) (string, int, bool) {
+	c := s[i]
+	n := len(s[i:]) - len(strings.TrimLeft(s[i:], string(c)))
+	if c == '~' && n != 2 {
+		return "", 0, false
+	}
+	// An _ inside a word, as in snake_case, is literal.
+	if c == '_' && i > 0 && isAlnum(s[i-1]) {
+		return "", 0, false
+	}
+	for _, size := range []int{2, 1} {
+		if n < size || (c == '~' && size == 1) {
+			continue
+		}
+		delim := strings.Repeat(string(c), size)
+		start := i + size
+		if start >= len(s) || isSpace(s[start]) {
+			continue
+		}
+		end := closingDelimiter(s[start-1:], delim)
+		if end <= 1 {
+			continue
+		}
+		inner := renderInline(s[start : start-1+end])
+		tag := "em"
+		switch {
+		case c == '~':
+			tag = "del"
+		case size == 2:
+			tag = "strong"
+		}
+		return fmt.Sprintf("<%s>%s</%s>", tag, inner, tag), start - 1 + end + size, true
+	}
+	return "", 0, false
+}
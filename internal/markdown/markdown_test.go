@@ -0,0 +1,84 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name, source, want string
+	}{
+		{"paragraph", "Login *fails* on **Safari**", "<p>Login <em>fails</em> on <strong>Safari</strong></p>"},
+		{"heading", "## Steps to reproduce ##", "<h2>Steps to reproduce</h2>"},
+		{"code span", "set `max_conns` to 0", "<p>set <code>max_conns</code> to 0</p>"},
+		{"snake case", "call get_user_by_id", "<p>call get_user_by_id</p>"},
+		{"strikethrough", "~~fixed~~ broken again", "<p><del>fixed</del> broken again</p>"},
+		{"hard break", "first  \nsecond", "<p>first<br>\nsecond</p>"},
+		{"fenced code", "```sql\nSELECT 1 < 2;\n```", "<pre><code class=\"language-sql\">SELECT 1 &lt; 2;\n</code></pre>"},
+		{"indented code", "    $ make test", "<pre><code>$ make test\n</code></pre>"},
+		{"blockquote", "> expected 200\n> got 500", "<blockquote>\n<p>expected 200\ngot 500</p>\n</blockquote>"},
+		{"rule", "***", "<hr>"},
+		{
+			"ordered list", "3. open\n4. close",
+			"<ol start=\"3\">\n<li>open</li>\n<li>close</li>\n</ol>",
+		},
+		{
+			"task list", "- [x] reproduced\n- [ ] fixed",
+			"<ul>\n<li class=\"task-list-item\"><input type=\"checkbox\" checked disabled> reproduced</li>\n<li class=\"task-list-item\"><input type=\"checkbox\" disabled> fixed</li>\n</ul>",
+		},
+		{
+			"nested list", "- browsers\n  - Firefox\n  - Safari",
+			"<ul>\n<li>browsers\n<ul>\n<li>Firefox</li>\n<li>Safari</li>\n</ul></li>\n</ul>",
+		},
+		{
+			"loose list", "- one\n\n- two",
+			"<ul>\n<li><p>one</p></li>\n<li><p>two</p></li>\n</ul>",
+		},
+		{
+			"table", "| Browser | Works |\n|:--|:-:|\n| Firefox | yes |\n| `a\\|b` | no |",
+			"<table>\n<thead>\n<tr><th align=\"left\">Browser</th><th align=\"center\">Works</th></tr>\n</thead>\n<tbody>\n" +
+				"<tr><td align=\"left\">Firefox</td><td align=\"center\">yes</td></tr>\n" +
+				"<tr><td align=\"left\"><code>a|b</code></td><td align=\"center\">no</td></tr>\n</tbody>\n</table>",
+		},
+		{
+			"link", "see [the docs](https://example.com/a?b=1&c=2)",
+			"<p>see <a href=\"https://example.com/a?b=1&amp;c=2\" rel=\"nofollow noopener noreferrer\">the docs</a></p>",
+		},
+		{
+			"autolink", "<https://example.com>",
+			"<p><a href=\"https://example.com\" rel=\"nofollow noopener noreferrer\">https://example.com</a></p>",
+		},
+		{"relative link", "[trace](/api/bugs/API-1/attachments)", "<p><a href=\"/api/bugs/API-1/attachments\" rel=\"nofollow noopener noreferrer\">trace</a></p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Render(tt.source))
+		})
+	}
+}
+
+func TestRenderSanitizes(t *testing.T) {
+	tests := []struct {
+		name, source, want string
+	}{
+		{"raw html", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"html attribute", `<img src=x onerror="alert(1)">`, "<p>&lt;img src=x onerror=&quot;alert(1)&quot;&gt;</p>"},
+		{"javascript link", "[click](javascript:alert(document.cookie))", "<p>click</p>"},
+		{"uppercase scheme", "[click](JaVaScRiPt:alert(1))", "<p>click</p>"},
+		{"data image", "![x](data:image/svg+xml;base64,PHN2Zz4=)", "<p>x</p>"},
+		{"vbscript autolink", "<vbscript:msgbox>", "<p>&lt;vbscript:msgbox&gt;</p>"},
+		{"quote breaking attribute", `[x](https://example.com/"onmouseover="alert(1))`, `<p><a href="https://example.com/&quot;onmouseover=&quot;alert(1)" rel="nofollow noopener noreferrer">x</a></p>`},
+		{"code language", "```\"><script>\nx\n```", "<pre><code>x\n</code></pre>"},
+		{"table cell", "| a |\n|---|\n| <iframe> |", "<table>\n<thead>\n<tr><th>a</th></tr>\n</thead>\n<tbody>\n<tr><td>&lt;iframe&gt;</td></tr>\n</tbody>\n</table>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Render(tt.source)
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, strings.ToLower(got), "<script")
+		})
+	}
+}
@@ -70,6 +70,22 @@ func Authenticate(secret string, db *database.Queries) func(http.Handler) http.H
 	}
 }
 
+// Identify is Authenticate for routes anyone may read: requests without an
+// Authorization header go through anonymously, any other request needs a valid token.
+func Identify(secret string, db *database.Queries) func(http.Handler) http.Handler {
+	authenticate := Authenticate(secret, db)
+	return func(next http.Handler) http.Handler {
+		authenticated := authenticate(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
+
 func RevokeTokenAthenticate(db RefreshTokenFetcher) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
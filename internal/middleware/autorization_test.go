@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizationMatchesPolicyPaths(t *testing.T) {
+	enforcer, err := casbin.NewEnforcer("../../rbac_model.conf", "../../rbac_policy.csv")
+	if err != nil {
+		t.Fatalf("cannot create enforcer: %v", err)
+	}
+	handler := Authorization(enforcer)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		role, method, path string
+		want               int
+	}{
+		// a placeholder stands for exactly one path segment
+		{"admin", "DELETE", "/api/bugs/87f0ea02-7b24-41bd-8418-0831a019fc87", http.StatusNoContent},
+		{"admin", "DELETE", "/api/labels/5b0c6b8e-8f0e-4d1a-9a3c-2f6d7e8f9a0b", http.StatusNoContent},
+		{"admin", "DELETE", "/api/labels/5b0c6b8e-8f0e-4d1a-9a3c-2f6d7e8f9a0b/extra", http.StatusForbidden},
+		{"admin", "POST", "/api/bugs/BUG-1/merge", http.StatusNoContent},
+		// exact paths still match only themselves
+		{"admin", "POST", "/api/bugs", http.StatusNoContent},
+		{"admin", "POST", "/api/bugs/bulk", http.StatusForbidden},
+		{"admin", "POST", "/api/labels/ui", http.StatusForbidden},
+		{"admin", "GET", "/api/bugs/trash", http.StatusNoContent},
+		{"admin", "GET", "/api/bugs/trash/87f0ea02-7b24-41bd-8418-0831a019fc87", http.StatusForbidden},
+		{"admin", "PUT", "/api/sla/P1/extra", http.StatusForbidden},
+		// the method and role still have to match
+		{"admin", "PUT", "/api/bugs/BUG-1", http.StatusForbidden},
+		{"user", "DELETE", "/api/bugs/BUG-1", http.StatusForbidden},
+		{"user", "POST", "/api/labels", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), "role", tt.role))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, tt.want, w.Code, "%s %s as %s", tt.method, tt.path, tt.role)
+	}
+}
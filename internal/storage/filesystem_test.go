@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesystemPutAndOpen(t *testing.T) {
+	store, err := NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create storage: %v", err)
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, store.Put(ctx, "abc123", strings.NewReader("first")))
+	// a second Put under the same key keeps the first contents
+	assert.NoError(t, store.Put(ctx, "abc123", strings.NewReader("second")))
+
+	file, err := store.Open(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("cannot open stored file: %v", err)
+	}
+	defer file.Close()
+	contents, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", string(contents))
+
+	_, err = store.Open(ctx, "missing")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestFilesystemRejectsPathKeys(t *testing.T) {
+	store, err := NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create storage: %v", err)
+	}
+	assert.Error(t, store.Put(context.Background(), "../../etc/passwd", strings.NewReader("x")))
+	_, err = store.Open(context.Background(), "..")
+	assert.Error(t, err)
+}
+
+func TestFilesystemDelete(t *testing.T) {
+	store, err := NewFilesystem(t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot create storage: %v", err)
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, store.Put(ctx, "abc123", strings.NewReader("contents")))
+	assert.NoError(t, store.Delete(ctx, "abc123"))
+	_, err = store.Open(ctx, "abc123")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	// deleting again is not an error
+	assert.NoError(t, store.Delete(ctx, "abc123"))
+}
@@ -0,0 +1,24 @@
+// Package storage keeps uploaded file contents out of the database.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned by Open when nothing is stored under the key.
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage stores blobs under keys chosen by the caller. Keys are plain names such as
+// content hashes; they never contain path separators.
+type Storage interface {
+	// Put stores the contents of r under key. Storing under a key that already exists
+	// keeps the existing contents, so content-addressed callers can Put unconditionally.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open returns the contents stored under key, or ErrNotFound.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the contents stored under key. Deleting a missing key is not an
+	// error.
+	Delete(ctx context.Context, key string) error
+}